@@ -0,0 +1,238 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+	"github.com/Bhupesh-V/godeping/utils"
+	"golang.org/x/mod/module"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the names of the configuration file looked up at the project root, in order
+var FileNames = []string{".godeping.yaml", ".godeping.yml", ".godeping.json"}
+
+// Config is the project-level configuration. Every setting is optional,
+// and flags given on the command line take precedence over it.
+type Config struct {
+	Since       string            `yaml:"since" json:"since"`             // Default unmaintained duration, e.g. 1y6m
+	Format      string            `yaml:"format" json:"format"`           // Output format
+	Ignore      []string          `yaml:"ignore" json:"ignore"`           // Module path patterns that are not checked
+	Thresholds  map[string]string `yaml:"thresholds" json:"thresholds"`   // Unmaintained duration per module path pattern
+	GoProxy     string            `yaml:"goproxy" json:"goproxy"`         // Used instead of $GOPROXY
+	GitHubAPI   *string           `yaml:"github_api" json:"github_api"`   // Empty to disable GitHub checks
+	Forges      []string          `yaml:"forges" json:"forges"`           // Extra forges as kind:host[=api-url]
+	Concurrency int               `yaml:"concurrency" json:"concurrency"` // Dependencies checked at the same time
+	RateLimit   *float64          `yaml:"rate_limit" json:"rate_limit"`   // Requests per second per host, 0 for no limit
+	FailOn      []string          `yaml:"fail_on" json:"fail_on"`         // Conditions that fail the run, see -fail-on
+	Accept      []Acceptance      `yaml:"accept" json:"accept"`           // Findings acknowledged as accepted risk
+}
+
+// Acceptance acknowledges the findings about the modules matching Module as accepted risk
+type Acceptance struct {
+	Module  string `yaml:"module" json:"module"`   // Module path pattern, a glob matching path prefixes like github.com/pkg/*
+	Reason  string `yaml:"reason" json:"reason"`   // Why the risk is accepted
+	Owner   string `yaml:"owner" json:"owner"`     // Who is responsible for it
+	Expires string `yaml:"expires" json:"expires"` // Last day the acceptance applies, as YYYY-MM-DD
+}
+
+// acceptanceDateFormat is the layout of Acceptance.Expires
+const acceptanceDateFormat = "2006-01-02"
+
+// DropEndpoints clears the settings that choose where requests go, and returns the names of the
+// ones that were set. A configuration file found in the project may come from an untrusted
+// checkout, and these settings would send tokens and private module paths to any host.
+func (c *Config) DropEndpoints() []string {
+	var dropped []string
+	if c.GoProxy != "" {
+		dropped = append(dropped, "goproxy")
+	}
+	if c.GitHubAPI != nil {
+		dropped = append(dropped, "github_api")
+	}
+	if len(c.Forges) > 0 {
+		dropped = append(dropped, "forges")
+	}
+	c.GoProxy, c.GitHubAPI, c.Forges = "", nil, nil
+	return dropped
+}
+
+// Find returns the path of the configuration file in dir, or an empty string if there is none
+func Find(dir string) (string, error) {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
+		_, err := os.Stat(path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// Load reads a configuration file, as JSON if its name ends in .json and as YAML otherwise.
+// Unknown settings are rejected so that typos don't go unnoticed.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+	}
+	// An empty file is an empty configuration
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid configuration file %s: %v", path, err)
+	}
+
+	for _, pattern := range cfg.Ignore {
+		if err := validatePattern(pattern); err != nil {
+			return nil, fmt.Errorf("invalid configuration file %s: ignore: %v", path, err)
+		}
+	}
+	if _, err := cfg.ThresholdDurations(); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %v", path, err)
+	}
+	for i, accept := range cfg.Accept {
+		if err := accept.validate(); err != nil {
+			return nil, fmt.Errorf("invalid configuration file %s: accept entry %d: %v", path, i+1, err)
+		}
+	}
+	return cfg, nil
+}
+
+// FlagValues returns the command line flags equivalent to the settings, keyed by flag name.
+// Repeatable flags have one value per occurrence.
+func (c *Config) FlagValues() map[string][]string {
+	values := make(map[string][]string)
+	if c.Since != "" {
+		values["since"] = []string{c.Since}
+	}
+	if c.Format != "" {
+		values["format"] = []string{c.Format}
+	}
+	if c.GoProxy != "" {
+		values["goproxy"] = []string{c.GoProxy}
+	}
+	if c.GitHubAPI != nil {
+		values["github-api"] = []string{*c.GitHubAPI}
+	}
+	if len(c.Forges) > 0 {
+		values["forge"] = c.Forges
+	}
+	if c.Concurrency != 0 {
+		values["concurrency"] = []string{strconv.Itoa(c.Concurrency)}
+	}
+	if c.RateLimit != nil {
+		values["rate-limit"] = []string{strconv.FormatFloat(*c.RateLimit, 'g', -1, 64)}
+	}
+	if len(c.FailOn) > 0 {
+		values["fail-on"] = c.FailOn
+	}
+	return values
+}
+
+// Ignored reports whether modPath matches one of the ignore patterns. Patterns are globs
+// matched against path prefixes, like the ones in GOPRIVATE.
+func (c *Config) Ignored(modPath string) bool {
+	return len(c.Ignore) > 0 && module.MatchPrefixPatterns(strings.Join(c.Ignore, ","), modPath)
+}
+
+// ThresholdDurations parses the per-module unmaintained durations
+func (c *Config) ThresholdDurations() (map[string]time.Duration, error) {
+	thresholds := make(map[string]time.Duration, len(c.Thresholds))
+	for pattern, since := range c.Thresholds {
+		if err := validatePattern(pattern); err != nil {
+			return nil, fmt.Errorf("invalid threshold for %s: %v", pattern, err)
+		}
+		d, err := utils.GetTimeDurationFromRelativeDate(since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold for %s: %v", pattern, err)
+		}
+		thresholds[pattern] = d
+	}
+	return thresholds, nil
+}
+
+// validate checks that an acceptance is complete, so that every accepted risk has someone
+// answering for it and resurfaces eventually
+func (a Acceptance) validate() error {
+	switch {
+	case a.Module == "":
+		return fmt.Errorf("module is required")
+	case a.Reason == "":
+		return fmt.Errorf("reason is required for %s", a.Module)
+	case a.Owner == "":
+		return fmt.Errorf("owner is required for %s", a.Module)
+	case a.Expires == "":
+		return fmt.Errorf("expires is required for %s", a.Module)
+	}
+	if err := validatePattern(a.Module); err != nil {
+		return err
+	}
+	if _, err := time.ParseInLocation(acceptanceDateFormat, a.Expires, time.Local); err != nil {
+		return fmt.Errorf("invalid expiry date for %s, expected YYYY-MM-DD: %v", a.Module, err)
+	}
+	return nil
+}
+
+// validatePattern checks a module path pattern. Patterns are matched one at a time by
+// module.MatchPrefixPatterns, which would read a comma as separating several of them.
+func validatePattern(pattern string) error {
+	if strings.Contains(pattern, ",") {
+		return fmt.Errorf("invalid module pattern %q: list each pattern separately instead of separating them with commas", pattern)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid module pattern %q: %v", pattern, err)
+	}
+	return nil
+}
+
+// Acceptance returns the acceptance covering modPath at the given time. An acceptance that
+// has lapsed is only returned, marked as expired, if no other one applies. It returns nil
+// if modPath isn't covered at all.
+func (c *Config) Acceptance(modPath string, now time.Time) *ping.Acceptance {
+	var expired *ping.Acceptance
+	for _, accept := range c.Accept {
+		if !module.MatchPrefixPatterns(accept.Module, modPath) {
+			continue
+		}
+		// Validated by Load, the acceptance applies until the end of that day in local time
+		expires, _ := time.ParseInLocation(acceptanceDateFormat, accept.Expires, time.Local)
+		acceptance := &ping.Acceptance{
+			Pattern: accept.Module,
+			Reason:  accept.Reason,
+			Owner:   accept.Owner,
+			Expires: expires,
+			Expired: !now.Before(expires.AddDate(0, 0, 1)),
+		}
+		if !acceptance.Expired {
+			return acceptance
+		}
+		if expired == nil {
+			expired = acceptance
+		}
+	}
+	return expired
+}
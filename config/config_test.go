@@ -0,0 +1,233 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile creates a file with the given content in dir and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := Find(dir)
+	if err != nil || path != "" {
+		t.Errorf("Expected no configuration file, got %q, %v", path, err)
+	}
+
+	writeFile(t, dir, ".godeping.json", "{}")
+	writeFile(t, dir, ".godeping.yaml", "")
+	path, err = Find(dir)
+	if err != nil || path != filepath.Join(dir, ".godeping.yaml") {
+		t.Errorf("Expected .godeping.yaml to be preferred, got %q, %v", path, err)
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), ".godeping.yaml", `
+since: 1y
+format: json
+ignore:
+  - github.com/pkg/errors
+  - golang.org/x/*
+thresholds:
+  github.com/golang/protobuf: 5y
+goproxy: https://proxy.example.com,direct
+github_api: ""
+forges:
+  - gitlab:gitlab.example.com
+  - gitea:git.example.com
+concurrency: 4
+rate_limit: 0
+fail_on:
+  - archived
+  - score<50
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := map[string][]string{
+		"since":       {"1y"},
+		"format":      {"json"},
+		"goproxy":     {"https://proxy.example.com,direct"},
+		"github-api":  {""},
+		"forge":       {"gitlab:gitlab.example.com", "gitea:git.example.com"},
+		"concurrency": {"4"},
+		"rate-limit":  {"0"},
+		"fail-on":     {"archived", "score<50"},
+	}
+	if got := cfg.FlagValues(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FlagValues() = %v, want %v", got, expected)
+	}
+
+	thresholds, err := cfg.ThresholdDurations()
+	if err != nil || len(thresholds) != 1 || thresholds["github.com/golang/protobuf"] == 0 {
+		t.Errorf("ThresholdDurations() = %v, %v", thresholds, err)
+	}
+
+	ignored := map[string]bool{
+		"github.com/pkg/errors":         true,
+		"github.com/pkg/errors/v2":      true,
+		"golang.org/x/net":              true,
+		"github.com/pkg/errorsx":        false,
+		"github.com/stretchr/testify":   false,
+		"google.golang.org/x/something": false,
+	}
+	for modPath, expected := range ignored {
+		if got := cfg.Ignored(modPath); got != expected {
+			t.Errorf("Ignored(%q) = %v, want %v", modPath, got, expected)
+		}
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	path := writeFile(t, t.TempDir(), ".godeping.json", `{"since": "6m", "concurrency": 2}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	expected := map[string][]string{"since": {"6m"}, "concurrency": {"2"}}
+	if got := cfg.FlagValues(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FlagValues() = %v, want %v", got, expected)
+	}
+	if cfg.Ignored("github.com/pkg/errors") {
+		t.Errorf("Nothing should be ignored without ignore patterns")
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	cfg, err := Load(writeFile(t, t.TempDir(), ".godeping.yaml", "# nothing yet\n"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.FlagValues(); len(got) != 0 {
+		t.Errorf("Expected no flag values, got %v", got)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		errorMsg string
+	}{
+		{name: "Unknown YAML setting", file: "a.yaml", content: "sinse: 1y\n", errorMsg: "field sinse not found"},
+		{name: "Unknown JSON setting", file: "b.json", content: `{"sinse": "1y"}`, errorMsg: `unknown field "sinse"`},
+		{name: "Wrong type", file: "c.yaml", content: "concurrency: lots\n", errorMsg: "cannot unmarshal"},
+		{name: "Invalid threshold", file: "d.yaml", content: "thresholds:\n  github.com/pkg/errors: forever\n", errorMsg: "invalid threshold for github.com/pkg/errors"},
+		{name: "Acceptance without owner", file: "e.yaml", content: "accept:\n  - module: github.com/pkg/errors\n    reason: Stable\n    expires: 2030-01-01\n", errorMsg: "accept entry 1: owner is required for github.com/pkg/errors"},
+		{name: "Acceptance without expiry", file: "f.yaml", content: "accept:\n  - module: github.com/pkg/errors\n    reason: Stable\n    owner: jdoe\n", errorMsg: "expires is required"},
+		{name: "Invalid expiry", file: "g.yaml", content: "accept:\n  - module: github.com/pkg/errors\n    reason: Stable\n    owner: jdoe\n    expires: next year\n", errorMsg: "invalid expiry date for github.com/pkg/errors"},
+		{name: "Invalid module pattern", file: "h.yaml", content: "accept:\n  - module: github.com/[pkg\n    reason: Stable\n    owner: jdoe\n    expires: 2030-01-01\n", errorMsg: "invalid module pattern"},
+		{name: "Several modules in an acceptance", file: "i.yaml", content: "accept:\n  - module: github.com/legacy/lib,github.com/other/*\n    reason: Stable\n    owner: jdoe\n    expires: 2030-01-01\n", errorMsg: "accept entry 1: invalid module pattern \"github.com/legacy/lib,github.com/other/*\": list each pattern separately"},
+		{name: "Several modules in an ignore pattern", file: "j.yaml", content: "ignore:\n  - github.com/pkg/errors,golang.org/x/*\n", errorMsg: "ignore: invalid module pattern"},
+		{name: "Several modules in a threshold", file: "k.yaml", content: "thresholds:\n  github.com/pkg/errors,golang.org/x/*: 5y\n", errorMsg: "invalid threshold for github.com/pkg/errors,golang.org/x/*: invalid module pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeFile(t, dir, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.errorMsg)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
+
+func TestAcceptance(t *testing.T) {
+	path := writeFile(t, t.TempDir(), ".godeping.yaml", `
+accept:
+  - module: github.com/pkg/errors
+    reason: Replaced by the standard library next quarter
+    owner: platform-team
+    expires: 2025-03-31
+  - module: github.com/legacy/*
+    reason: Frozen until the rewrite
+    owner: jdoe
+    expires: 2025-12-31
+  - module: github.com/pkg/errors
+    reason: Extended once
+    owner: platform-team
+    expires: 2025-01-31
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	day := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 12, 0, 0, 0, time.Local) }
+	tests := []struct {
+		modPath string
+		now     time.Time
+		owner   string // Empty if no acceptance is expected
+		expired bool
+	}{
+		{"github.com/pkg/errors", day(time.January, 15), "platform-team", false},
+		{"github.com/pkg/errors", day(time.March, 31), "platform-team", false},
+		{"github.com/pkg/errors", day(time.April, 1), "platform-team", true},
+		{"github.com/legacy/lib", day(time.June, 1), "jdoe", false},
+		{"github.com/legacy/lib/v2", day(time.June, 1), "jdoe", false},
+		{"github.com/legacyx/lib", day(time.June, 1), "", false},
+		{"github.com/pkg/errorsx", day(time.June, 1), "", false},
+	}
+	for _, tt := range tests {
+		acceptance := cfg.Acceptance(tt.modPath, tt.now)
+		if tt.owner == "" {
+			if acceptance != nil {
+				t.Errorf("Acceptance(%q) = %+v, want nil", tt.modPath, acceptance)
+			}
+			continue
+		}
+		if acceptance == nil || acceptance.Owner != tt.owner || acceptance.Expired != tt.expired {
+			t.Errorf("Acceptance(%q, %s) = %+v, want owner %s, expired %v", tt.modPath, tt.now.Format("2006-01-02"), acceptance, tt.owner, tt.expired)
+		}
+	}
+}
+
+func TestDropEndpoints(t *testing.T) {
+	path := writeFile(t, t.TempDir(), ".godeping.yaml", `
+since: 1y
+goproxy: https://attacker.example.com
+github_api: https://attacker.example.com/api
+forges:
+  - gitlab:attacker.example.com
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	dropped := cfg.DropEndpoints()
+	if expected := []string{"goproxy", "github_api", "forges"}; !reflect.DeepEqual(dropped, expected) {
+		t.Errorf("DropEndpoints() = %v, want %v", dropped, expected)
+	}
+	expected := map[string][]string{"since": {"1y"}}
+	if got := cfg.FlagValues(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FlagValues() = %v after dropping endpoints, want %v", got, expected)
+	}
+	if dropped := cfg.DropEndpoints(); len(dropped) != 0 {
+		t.Errorf("Expected nothing left to drop, got %v", dropped)
+	}
+}
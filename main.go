@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/Bhupesh-V/godeping/config"
 	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
 	ping "github.com/Bhupesh-V/godeping/ping"
 	"github.com/Bhupesh-V/godeping/report"
@@ -12,48 +19,147 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
 
-	jsonOutput := flag.Bool("json", false, "Output results in JSON format (useful for scripting)")
+	jsonOutput := flag.Bool("json", false, "Output results in JSON format (useful for scripting), same as -format json")
+	format := flag.String("format", "text", "Output format, one of text, json, sarif")
+	configFile := flag.String("config", "", "Configuration file to use instead of the .godeping.yaml, .godeping.yml or .godeping.json found in the project root. goproxy, github_api and forges are only read from a file given here")
+	goproxy := flag.String("goproxy", "", "Module proxies to query, with the same syntax as GOPROXY (default $GOPROXY)")
 	quiet := flag.Bool("quiet", false, "Suppress non-essential output (e.g., progress indicators)")
 	sinceFlag := flag.String("since", "2y", "Consider dependencies as unmaintained if not updated since this duration (e.g. 1y, 6m, 2y3m)")
+	githubAPI := flag.String("github-api", ping.DefaultGitHubAPI, "Base URL of the GitHub REST API used to detect archived repositories (empty to disable)")
+	offline := flag.Bool("offline", false, "Only read module metadata from the local module cache ($GOMODCACHE) and make no network requests")
+	noCache := flag.Bool("no-cache", false, "Don't read or write the on-disk response cache")
+	refresh := flag.Bool("refresh", false, "Ignore cached responses and fetch everything again (the cache is still updated)")
+	cacheTTL := flag.Duration("cache-ttl", ping.DefaultCacheTTL, "How long cached responses are reused")
+	timeout := flag.Duration("timeout", 0, "Stop checking after this long and report what was found so far (0 for no limit)")
+	requestTimeout := flag.Duration("request-timeout", ping.DefaultRequestTimeout, "Maximum time for a single HTTP request, including retries")
+	concurrency := flag.Int("concurrency", ping.DefaultConcurrency, "Number of dependencies checked at the same time")
+	rateLimit := flag.Float64("rate-limit", ping.DefaultRateLimit, "Maximum requests per second sent to any single host (0 for no limit)")
+	explain := flag.String("explain", "", "Check a single dependency and print the evidence its status is based on")
+	weightsSpec := flag.String("weights", "", "Health score weights as signal=weight pairs, e.g. recency=10,cadence=40 (signals: "+strings.Join(ping.HealthSignals, ", ")+")")
+	weightsFile := flag.String("weights-file", "", "JSON file with health score weights, e.g. {\"recency\": 10}; -weights overrides it")
+	cadence := flag.Bool("cadence", false, "Fetch the publish time of every version to work out each module's release cadence, and judge staleness against its own history instead of -since when it has enough releases")
+	vulns := flag.Bool("vulns", false, "Look up known vulnerabilities of the required versions in the Go vulnerability database ("+ping.DefaultVulnDB+")")
+	baselineFile := flag.String("baseline", "", "Baseline file written by -write-baseline; only findings missing from it, or worse than in it, are reported and fail the run")
+	diffBase := flag.String("diff-base", "", "Only check the dependencies added or changed since this git revision, e.g. origin/main")
+	writeBaseline := flag.String("write-baseline", "", "Write the current findings to this baseline file, and don't fail the run on them")
+	var forgeSpecs, failOn stringList
+	flag.Var(&failOn, "fail-on", "Exit with status 1 when dependencies match these conditions: archived, deprecated, not_found, stale, unknown, retracted, score<N, count>N (comma-separated, repeatable)")
+	flag.Var(&forgeSpecs, "forge", "Extra forge to check repositories on, as kind:host[=api-url] with kind one of github, gitlab, gitea, bitbucket (repeatable)")
 	flag.Usage = utils.GetUsageText()
 	flag.Parse()
 
-	// When JSON output is enabled, quiet mode is automatically turned on
-	if *jsonOutput {
-		*quiet = true
-	}
-
 	// Check for the required positional argument
 	args := flag.Args()
 	if len(args) < 1 {
 		fmt.Fprintf(os.Stderr, "Error: Path to Go project is required\n\n")
 		printUsage()
-		os.Exit(1)
+		os.Exit(utils.ExitError)
+	}
+
+	projectPath := args[0]
+
+	// Settings from the configuration file apply to the flags not given on the command line
+	configPath := *configFile
+	var err error
+	if configPath == "" {
+		if configPath, err = config.Find(projectPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to look for a configuration file: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+	}
+	cfg := &config.Config{}
+	if configPath != "" {
+		if cfg, err = config.Load(configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(utils.ExitError)
+		}
+		// Only a file given explicitly may redirect requests, which carry tokens and private module paths
+		if *configFile == "" {
+			if dropped := cfg.DropEndpoints(); len(dropped) > 0 {
+				fmt.Fprintf(os.Stderr, "Ignoring %s in %s, pass the file with -config to apply these settings\n", strings.Join(dropped, ", "), configPath)
+			}
+		}
+		explicit := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+		for name, values := range cfg.FlagValues() {
+			if explicit[name] {
+				continue
+			}
+			for _, value := range values {
+				if err := flag.Set(name, value); err != nil {
+					fmt.Fprintf(os.Stderr, "Invalid %s setting in %s: %v\n", name, configPath, err)
+					os.Exit(utils.ExitError)
+				}
+			}
+		}
+	}
+
+	if *jsonOutput {
+		*format = "json"
+	}
+	switch *format {
+	case "text":
+	case "json":
+		// When JSON output is enabled, quiet mode is automatically turned on
+		*jsonOutput = true
+		*quiet = true
+	case "sarif":
+		// Like JSON, the output must not be mixed with anything else
+		*quiet = true
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid -format flag: unknown format %q\n", *format)
+		os.Exit(utils.ExitError)
+	}
+
+	policy, err := report.ParsePolicy(failOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -fail-on flag: %v\n", err)
+		os.Exit(utils.ExitError)
+	}
+	if *baselineFile != "" && *writeBaseline != "" {
+		fmt.Fprintf(os.Stderr, "The -baseline and -write-baseline flags can't be used together\n")
+		os.Exit(utils.ExitError)
+	}
+	if *diffBase != "" && *writeBaseline != "" {
+		fmt.Fprintf(os.Stderr, "The -diff-base and -write-baseline flags can't be used together, a baseline needs every dependency checked\n")
+		os.Exit(utils.ExitError)
+	}
+	var baseline *report.Baseline
+	if *baselineFile != "" {
+		if baseline, err = report.LoadBaseline(*baselineFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -baseline flag: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
 	}
 
 	// Parse the duration from the since flag
 	duration, err := utils.GetTimeDurationFromRelativeDate(*sinceFlag)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Invalid duration format for -since flag: %v\n", err)
-		os.Exit(1)
+		os.Exit(utils.ExitError)
 	}
 
-	projectPath := args[0]
-
 	// Example usage of the flags and args
 	if !*quiet {
 		fmt.Printf("Analyzing Go project at: %s\n", projectPath)
+		if configPath != "" {
+			fmt.Printf("Using configuration file: %s\n", configPath)
+		}
 	}
 
 	// Parse the go.mod file
 	moduleInfo, err := parser.ParseGoMod(projectPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Unable to parse go.mod file: %v\n", err)
-		os.Exit(1)
+		os.Exit(utils.ExitError)
 	}
 
-	if !*jsonOutput {
+	if *format == "text" {
 		fmt.Printf("Found %d dependencies in go.mod\n", len(moduleInfo.Requires))
 		fmt.Printf("Module: %s\n", moduleInfo.ModuleName)
 		fmt.Printf("Go Version: %s\n", moduleInfo.GoVersion)
@@ -61,18 +167,263 @@ func main() {
 
 	// Always check for archived GitHub dependencies
 	client := ping.NewClient()
+	if *goproxy == "" {
+		*goproxy = os.Getenv("GOPROXY")
+	}
+	if err := client.SetGoProxy(*goproxy); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid GOPROXY: %v\n", err)
+		os.Exit(utils.ExitError)
+	}
+	client.SetPrivatePatterns(ping.PrivatePatternsFromEnv())
+	// Resolve vanity import paths, then check repositories on the public forges, then on any configured self-hosted ones
+	client.SetDiscoverRepos(true)
+	tokens := map[string]string{
+		"github":    os.Getenv("GITHUB_TOKEN"),
+		"gitlab":    os.Getenv("GITLAB_TOKEN"),
+		"gitea":     os.Getenv("GITEA_TOKEN"),
+		"bitbucket": os.Getenv("BITBUCKET_TOKEN"),
+	}
+	for _, forge := range ping.DefaultForges(tokens) {
+		if forge.Host() == "github.com" {
+			if *githubAPI == "" {
+				continue
+			}
+			forge, _ = ping.NewForge("github", "github.com", *githubAPI, tokens["github"])
+		}
+		client.AddForge(forge)
+	}
+	for _, spec := range forgeSpecs {
+		kind, _, _ := strings.Cut(spec, ":")
+		forge, err := ping.ParseForge(spec, tokens[kind])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -forge flag: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+		client.AddForge(forge)
+	}
+	if *offline {
+		if err := client.SetOffline(ping.DefaultModCache()); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to use the module cache: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+	}
+	if !*noCache {
+		if cacheDir, err := ping.DefaultCacheDir(); err == nil {
+			cache := ping.NewCache(cacheDir, *cacheTTL)
+			cache.SetRefresh(*refresh)
+			client.SetCache(cache)
+		} else if !*quiet {
+			fmt.Fprintf(os.Stderr, "Response cache disabled: %v\n", err)
+		}
+	}
+	weights := ping.DefaultHealthWeights()
+	if *weightsFile != "" {
+		if weights, err = ping.LoadHealthWeights(*weightsFile, weights); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -weights-file flag: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+	}
+	if *weightsSpec != "" {
+		if weights, err = ping.ParseHealthWeights(*weightsSpec, weights); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -weights flag: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+	}
+	client.SetHealthWeights(weights)
+	if *vulns {
+		client.SetVulnDB(ping.DefaultVulnDB)
+	}
+	client.SetCadence(*cadence)
+	client.SetRequestTimeout(*requestTimeout)
+	client.SetConcurrency(*concurrency)
+	client.SetRateLimit(*rateLimit)
 	client.SetUnmaintainedDuration(duration)
+	thresholds, _ := cfg.ThresholdDurations() // Validated by config.Load
+	for pattern, d := range thresholds {
+		client.SetUnmaintainedDurationFor(pattern, d)
+	}
 	client.SetProgressCallback(utils.ProgressCallback(quiet))
-	archivedResults := client.PingPackage(
-		moduleInfo.Requires,
-	)
+
+	// Ctrl-C or the -timeout deadline cancel in-flight requests, and a partial report is still printed.
+	// Once cancelled, signals are no longer caught so that a second Ctrl-C exits immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	context.AfterFunc(ctx, stop)
+
+	if *explain != "" {
+		runExplain(ctx, client, cfg, moduleInfo, *explain, *jsonOutput)
+		return
+	}
+
+	requires := moduleInfo.Requires
+	if *diffBase != "" {
+		baseInfo, err := parser.ParseGoModAtRevision(projectPath, *diffBase)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -diff-base flag: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+		// Indirect requirements are never checked, so only direct ones are counted
+		requires = nil
+		for _, dep := range parser.ChangedRequires(baseInfo, moduleInfo) {
+			if !dep.Indirect {
+				requires = append(requires, dep)
+			}
+		}
+		if !*quiet {
+			fmt.Printf("Checking %d direct dependencies added or changed since %s\n", len(requires), *diffBase)
+		}
+	}
+
+	var deps []parser.Dependency
+	for _, dep := range requires {
+		if !cfg.Ignored(dep.Path) {
+			deps = append(deps, dep)
+		}
+	}
+	if ignored := len(requires) - len(deps); ignored > 0 && !*quiet {
+		fmt.Printf("Ignoring %d dependencies listed in %s\n", ignored, configPath)
+	}
+	archivedResults := client.PingPackage(ctx, deps)
+	acceptRisks(cfg, archivedResults)
+	var baselineDiff report.BaselineDiff
+	if baseline != nil {
+		baselineDiff = baseline.Apply(archivedResults, policy)
+		if *diffBase != "" {
+			// Unchanged dependencies weren't checked, which doesn't make their entries stale
+			baselineDiff.Stale = nil
+		}
+	}
 
 	// Output the results using the appropriate format
-	if *jsonOutput {
+	switch *format {
+	case "json":
 		report.OutputJSON(moduleInfo, archivedResults)
-	} else {
+	case "sarif":
+		report.OutputSARIF(moduleInfo, archivedResults, filepath.Join(projectPath, "go.mod"))
+	default:
 		report.OutputText(moduleInfo, archivedResults)
 	}
+
+	if baseline != nil {
+		report.OutputBaselineDiff(baselineDiff, *baselineFile)
+	}
+	if *diffBase != "" {
+		report.OutputIntroduced(policy, archivedResults, *diffBase)
+	}
+	if *writeBaseline != "" {
+		written := report.NewBaseline(archivedResults, policy)
+		if err := written.Write(*writeBaseline); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to write the baseline: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+		fmt.Fprintf(os.Stderr, "\nWrote %d findings to the baseline %s\n", len(written.Findings), *writeBaseline)
+	} else {
+		report.OutputViolations(policy, archivedResults)
+	}
+	if ctx.Err() != nil {
+		fmt.Fprintf(os.Stderr, "Incomplete run: %v\n", context.Cause(ctx))
+	}
+	// A violation found in partial results stands, more results can only add to it
+	switch {
+	case *writeBaseline == "" && policy.Violated(archivedResults):
+		os.Exit(utils.ExitPolicyViolation)
+	case report.Partial(archivedResults):
+		os.Exit(utils.ExitPartial)
+	}
+}
+
+// runExplain checks the single dependency modPath and prints the evidence trail behind its status
+func runExplain(ctx context.Context, client *ping.Client, cfg *config.Config, moduleInfo *parser.ModuleInfo, modPath string, jsonOutput bool) {
+	var dep *parser.Dependency
+	for _, req := range moduleInfo.Requires {
+		if req.Path == modPath {
+			dep = &req
+			break
+		}
+	}
+	if dep == nil {
+		fmt.Fprintf(os.Stderr, "%s is not required by %s\n", modPath, moduleInfo.ModuleName)
+		os.Exit(utils.ExitError)
+	}
+
+	// Indirect dependencies are explained too, PingPackage only skips them in bulk runs
+	dep.Indirect = false
+	results := client.PingPackage(ctx, []parser.Dependency{*dep})
+	acceptRisks(cfg, results)
+	if jsonOutput {
+		report.OutputExplainJSON(results[0])
+	} else {
+		report.OutputExplain(results[0])
+	}
+}
+
+// runDiff compares two reports written with -json and prints what changed between them
+func runDiff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "Output the differences in JSON format, same as -format json")
+	format := flags.String("format", "text", "Output format, one of text, json")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [options] <old.json> <new.json>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "Error: Two reports written with -json are required\n\n")
+		flags.Usage()
+		os.Exit(utils.ExitError)
+	}
+	if *jsonOutput {
+		*format = "json"
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Invalid -format flag: unknown format %q\n", *format)
+		os.Exit(utils.ExitError)
+	}
+
+	var reports [2]*report.SavedReport
+	for i, path := range flags.Args() {
+		saved, err := report.LoadReport(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to read the report: %v\n", err)
+			os.Exit(utils.ExitError)
+		}
+		reports[i] = saved
+	}
+
+	diff := report.DiffReports(reports[0], reports[1])
+	if *format == "json" {
+		report.OutputDiffJSON(diff)
+	} else {
+		fmt.Printf("Comparing %s with %s\n", flags.Arg(0), flags.Arg(1))
+		report.OutputDiffText(diff)
+	}
+}
+
+// acceptRisks attaches the acceptances listed in the configuration file to the results they cover
+func acceptRisks(cfg *config.Config, results []ping.RepoStatus) {
+	now := time.Now()
+	for i := range results {
+		results[i].Accepted = cfg.Acceptance(results[i].ModulePath, now)
+	}
+}
+
+// stringList is a flag.Value collecting every occurrence of a repeatable flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
 }
 
 func printUsage() {
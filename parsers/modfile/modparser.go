@@ -6,13 +6,16 @@ import (
 	"path/filepath"
 
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
 )
 
 // ModuleInfo contains relevant information from a go.mod file
 type ModuleInfo struct {
 	ModuleName string
 	GoVersion  string
+	Deprecated string // Message of a "// Deprecated:" comment on the module directive
 	Requires   []Dependency
+	Retracts   []Retraction
 }
 
 // Dependency represents a module dependency
@@ -20,6 +23,19 @@ type Dependency struct {
 	Path     string
 	Version  string
 	Indirect bool
+	Line     int // Line of the require directive in go.mod, 0 if unknown
+}
+
+// Retraction is a range of versions withdrawn by a module's author with a retract directive
+type Retraction struct {
+	Low       string
+	High      string
+	Rationale string
+}
+
+// Covers reports whether version lies within the retracted range
+func (r Retraction) Covers(version string) bool {
+	return semver.Compare(r.Low, version) <= 0 && semver.Compare(version, r.High) <= 0
 }
 
 // ParseGoMod reads and parses a go.mod file from the specified project path
@@ -37,10 +53,31 @@ func ParseGoMod(projectPath string) (*ModuleInfo, error) {
 		return nil, fmt.Errorf("failed to parse go.mod: %v", err)
 	}
 
-	// Extract information
+	return newModuleInfo(f), nil
+}
+
+// ParseDependencyGoMod parses the go.mod file of a dependency, as served by a module proxy.
+// Like the go command it ignores directives that only matter to the main module.
+func ParseDependencyGoMod(file string, data []byte) (*ModuleInfo, error) {
+	f, err := modfile.ParseLax(file, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", file, err)
+	}
+	if f.Module == nil {
+		return nil, fmt.Errorf("failed to parse %s: missing module directive", file)
+	}
+
+	return newModuleInfo(f), nil
+}
+
+// newModuleInfo extracts the relevant information from a parsed go.mod file
+func newModuleInfo(f *modfile.File) *ModuleInfo {
 	info := &ModuleInfo{
 		ModuleName: f.Module.Mod.Path,
-		GoVersion:  f.Go.Version,
+		Deprecated: f.Module.Deprecated,
+	}
+	if f.Go != nil {
+		info.GoVersion = f.Go.Version
 	}
 
 	// Add dependencies
@@ -49,8 +86,17 @@ func ParseGoMod(projectPath string) (*ModuleInfo, error) {
 			Path:     req.Mod.Path,
 			Version:  req.Mod.Version,
 			Indirect: req.Indirect,
+			Line:     req.Syntax.Start.Line,
+		})
+	}
+
+	for _, retract := range f.Retract {
+		info.Retracts = append(info.Retracts, Retraction{
+			Low:       retract.Low,
+			High:      retract.High,
+			Rationale: retract.Rationale,
 		})
 	}
 
-	return info, nil
+	return info
 }
@@ -46,11 +46,13 @@ require (
 					Path:     "github.com/pkg/errors",
 					Version:  "v0.9.1",
 					Indirect: false,
+					Line:     6,
 				},
 				{
 					Path:     "github.com/stretchr/testify",
 					Version:  "v1.7.0",
 					Indirect: true,
+					Line:     7,
 				},
 			},
 		}
@@ -69,7 +71,7 @@ require (
 
 		for i, dep := range info.Requires {
 			expectedDep := expectedInfo.Requires[i]
-			if dep.Path != expectedDep.Path || dep.Version != expectedDep.Version || dep.Indirect != expectedDep.Indirect {
+			if dep != expectedDep {
 				t.Errorf("Dependency %d mismatch:\ngot: %+v\nwant: %+v", i, dep, expectedDep)
 			}
 		}
@@ -176,11 +178,11 @@ require (
 		}
 
 		expectedDeps := []Dependency{
-			{Path: "github.com/pkg/errors", Version: "v0.9.1", Indirect: false},
-			{Path: "github.com/sirupsen/logrus", Version: "v1.9.0", Indirect: false},
-			{Path: "github.com/spf13/cobra", Version: "v1.5.0", Indirect: true},
-			{Path: "github.com/stretchr/testify", Version: "v1.8.0", Indirect: true},
-			{Path: "golang.org/x/sys", Version: "v0.0.0-20220811171246-fbc7d0a398ab", Indirect: true},
+			{Path: "github.com/pkg/errors", Version: "v0.9.1", Indirect: false, Line: 5},
+			{Path: "github.com/sirupsen/logrus", Version: "v1.9.0", Indirect: false, Line: 6},
+			{Path: "github.com/spf13/cobra", Version: "v1.5.0", Indirect: true, Line: 7},
+			{Path: "github.com/stretchr/testify", Version: "v1.8.0", Indirect: true, Line: 8},
+			{Path: "golang.org/x/sys", Version: "v0.0.0-20220811171246-fbc7d0a398ab", Indirect: true, Line: 9},
 		}
 
 		if !reflect.DeepEqual(info.Requires, expectedDeps) {
@@ -188,3 +190,104 @@ require (
 		}
 	})
 }
+
+func TestParseDependencyGoMod(t *testing.T) {
+	t.Run("DeprecatedModule", func(t *testing.T) {
+		content := `// Deprecated: use github.com/example/newlib instead.
+module github.com/example/oldlib
+
+require github.com/pkg/errors v0.9.1
+
+replace github.com/pkg/errors => ../errors
+`
+		info, err := ParseDependencyGoMod("github.com/example/oldlib@v1.2.0/go.mod", []byte(content))
+		if err != nil {
+			t.Fatalf("ParseDependencyGoMod returned error: %v", err)
+		}
+
+		if info.ModuleName != "github.com/example/oldlib" {
+			t.Errorf("ModuleName = %q, want %q", info.ModuleName, "github.com/example/oldlib")
+		}
+		if info.Deprecated != "use github.com/example/newlib instead." {
+			t.Errorf("Deprecated = %q, want %q", info.Deprecated, "use github.com/example/newlib instead.")
+		}
+		if info.GoVersion != "" {
+			t.Errorf("GoVersion = %q, want empty", info.GoVersion)
+		}
+		if len(info.Requires) != 1 {
+			t.Errorf("Got %d dependencies, want 1", len(info.Requires))
+		}
+	})
+
+	t.Run("UnknownDirectivesIgnored", func(t *testing.T) {
+		content := `module github.com/example/lib
+
+go 1.99
+
+futuredirective something
+`
+		info, err := ParseDependencyGoMod("go.mod", []byte(content))
+		if err != nil {
+			t.Fatalf("ParseDependencyGoMod returned error: %v", err)
+		}
+		if info.Deprecated != "" {
+			t.Errorf("Deprecated = %q, want empty", info.Deprecated)
+		}
+	})
+
+	t.Run("MissingModuleDirective", func(t *testing.T) {
+		_, err := ParseDependencyGoMod("go.mod", []byte("go 1.21\n"))
+		if err == nil {
+			t.Fatal("Expected error for go.mod without module directive, got nil")
+		}
+	})
+}
+
+func TestRetractions(t *testing.T) {
+	content := `module github.com/example/lib
+
+go 1.21
+
+retract v1.0.5 // Published accidentally.
+
+retract (
+	[v1.1.0, v1.1.3] // Data race in the connection pool.
+	v1.2.0-rc.1
+)
+`
+	info, err := ParseDependencyGoMod("go.mod", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseDependencyGoMod returned error: %v", err)
+	}
+
+	expected := []Retraction{
+		{Low: "v1.0.5", High: "v1.0.5", Rationale: "Published accidentally."},
+		{Low: "v1.1.0", High: "v1.1.3", Rationale: "Data race in the connection pool."},
+		{Low: "v1.2.0-rc.1", High: "v1.2.0-rc.1"},
+	}
+	if !reflect.DeepEqual(info.Retracts, expected) {
+		t.Fatalf("Retracts mismatch.\nGot: %+v\nWant: %+v", info.Retracts, expected)
+	}
+
+	tests := []struct {
+		version string
+		covered bool
+	}{
+		{version: "v1.0.5", covered: true},
+		{version: "v1.0.6", covered: false},
+		{version: "v1.1.0", covered: true},
+		{version: "v1.1.2", covered: true},
+		{version: "v1.1.4", covered: false},
+		{version: "v1.2.0-rc.1", covered: true},
+		{version: "v1.2.0", covered: false},
+	}
+	for _, tt := range tests {
+		covered := false
+		for _, r := range info.Retracts {
+			covered = covered || r.Covers(tt.version)
+		}
+		if covered != tt.covered {
+			t.Errorf("Covers(%s) = %v, want %v", tt.version, covered, tt.covered)
+		}
+	}
+}
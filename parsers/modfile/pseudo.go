@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// PseudoVersion is a version the go command made up for an untagged commit,
+// such as v0.0.0-20210101120000-abcdef123456. It comes in three forms:
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef      no earlier tagged version
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef after the pre-release vX.Y.Z-pre
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef after the release vX.Y.Z
+//
+// each of which may carry a +incompatible suffix.
+type PseudoVersion struct {
+	Base         string    // Tagged version the commit follows, empty for the first form
+	Time         time.Time // Commit time in UTC
+	Revision     string    // Abbreviated commit hash
+	Incompatible bool      // The version has a +incompatible suffix
+}
+
+// ParsePseudoVersion splits a pseudo-version into its parts. It returns an error
+// if v is not a pseudo-version.
+func ParsePseudoVersion(v string) (*PseudoVersion, error) {
+	if !module.IsPseudoVersion(v) {
+		return nil, fmt.Errorf("%s is not a pseudo-version", v)
+	}
+
+	base, err := module.PseudoVersionBase(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := module.PseudoVersionTime(v)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := module.PseudoVersionRev(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PseudoVersion{
+		Base:         strings.TrimSuffix(base, "+incompatible"),
+		Time:         t,
+		Revision:     rev,
+		Incompatible: semver.Build(v) == "+incompatible",
+	}, nil
+}
+
+// PseudoVersion parses the required version of the dependency if it is a
+// pseudo-version, and returns nil otherwise
+func (d Dependency) PseudoVersion() *PseudoVersion {
+	pv, err := ParsePseudoVersion(d.Version)
+	if err != nil {
+		return nil
+	}
+	return pv
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePseudoVersion(t *testing.T) {
+	commitTime := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		version  string
+		expected *PseudoVersion
+	}{
+		{
+			name:     "No base version",
+			version:  "v0.0.0-20210101120000-abcdef123456",
+			expected: &PseudoVersion{Time: commitTime, Revision: "abcdef123456"},
+		},
+		{
+			name:     "After a release",
+			version:  "v1.2.4-0.20210101120000-abcdef123456",
+			expected: &PseudoVersion{Base: "v1.2.3", Time: commitTime, Revision: "abcdef123456"},
+		},
+		{
+			name:     "After a pre-release",
+			version:  "v1.3.0-beta.2.0.20210101120000-abcdef123456",
+			expected: &PseudoVersion{Base: "v1.3.0-beta.2", Time: commitTime, Revision: "abcdef123456"},
+		},
+		{
+			name:     "Incompatible",
+			version:  "v2.0.1-0.20210101120000-abcdef123456+incompatible",
+			expected: &PseudoVersion{Base: "v2.0.0", Time: commitTime, Revision: "abcdef123456", Incompatible: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv, err := ParsePseudoVersion(tt.version)
+			if err != nil {
+				t.Fatalf("ParsePseudoVersion(%q) returned error: %v", tt.version, err)
+			}
+			if !reflect.DeepEqual(pv, tt.expected) {
+				t.Errorf("ParsePseudoVersion(%q) = %+v, want %+v", tt.version, pv, tt.expected)
+			}
+		})
+	}
+
+	invalid := []string{
+		"v1.2.3",
+		"v1.2.3-rc.1",
+		"v0.0.0-20211301120000-abcdef123456",
+		"v0.0.0-20210101120000-abcdef123456+incompatible",
+		"not-a-version",
+	}
+	for _, version := range invalid {
+		if _, err := ParsePseudoVersion(version); err == nil {
+			t.Errorf("ParsePseudoVersion(%q) expected an error", version)
+		}
+	}
+
+	if pv := (Dependency{Path: "example.com/mod", Version: "v1.0.0"}).PseudoVersion(); pv != nil {
+		t.Errorf("Expected no pseudo-version for a tagged release, got %+v", pv)
+	}
+}
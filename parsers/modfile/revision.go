@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ParseGoModAtRevision parses the go.mod file of the project at projectPath as it was at
+// a git revision, e.g. a branch name or a commit hash. It is read with the local git.
+func ParseGoModAtRevision(projectPath, rev string) (*ModuleInfo, error) {
+	// git would read a revision starting with a dash as an option
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid git revision %q", rev)
+	}
+
+	// The ./ prefix makes git resolve the path relative to the project, which may be
+	// in a subdirectory of the repository
+	cmd := exec.Command("git", "show", rev+":./go.mod")
+	cmd.Dir = projectPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to read go.mod at %s: %s", rev, msg)
+		}
+		return nil, fmt.Errorf("failed to read go.mod at %s: %v", rev, err)
+	}
+
+	f, err := modfile.Parse(rev+":go.mod", data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod at %s: %v", rev, err)
+	}
+	return newModuleInfo(f), nil
+}
+
+// ChangedRequires returns the requirements of head that base doesn't have at the same version,
+// that is the dependencies added or updated (or downgraded) since base
+func ChangedRequires(base, head *ModuleInfo) []Dependency {
+	versions := make(map[string]string, len(base.Requires))
+	for _, dep := range base.Requires {
+		versions[dep.Path] = dep.Version
+	}
+
+	var changed []Dependency
+	for _, dep := range head.Requires {
+		if version, ok := versions[dep.Path]; !ok || version != dep.Version {
+			changed = append(changed, dep)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChangedRequires(t *testing.T) {
+	base := &ModuleInfo{Requires: []Dependency{
+		{Path: "github.com/kept/repo", Version: "v1.0.0"},
+		{Path: "github.com/bumped/repo", Version: "v1.0.0"},
+		{Path: "github.com/removed/repo", Version: "v0.1.0"},
+	}}
+	head := &ModuleInfo{Requires: []Dependency{
+		{Path: "github.com/kept/repo", Version: "v1.0.0"},
+		{Path: "github.com/bumped/repo", Version: "v1.2.0"},
+		{Path: "github.com/added/repo", Version: "v0.3.0", Indirect: true},
+	}}
+
+	expected := []Dependency{
+		{Path: "github.com/bumped/repo", Version: "v1.2.0"},
+		{Path: "github.com/added/repo", Version: "v0.3.0", Indirect: true},
+	}
+	if got := ChangedRequires(base, head); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ChangedRequires() = %v, want %v", got, expected)
+	}
+	if got := ChangedRequires(head, head); len(got) != 0 {
+		t.Errorf("Expected no changes, got %v", got)
+	}
+}
+
+func TestParseGoModAtRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// The project lives in a subdirectory of the repository
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "service")
+	if err := os.Mkdir(projectDir, 0o755); err != nil {
+		t.Fatalf("Failed to create project directory: %v", err)
+	}
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = projectDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, output)
+		}
+	}
+	writeGoMod := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write go.mod: %v", err)
+		}
+	}
+
+	git("init", "-q")
+	writeGoMod("module github.com/example/service\n\ngo 1.21\n\nrequire github.com/pkg/errors v0.9.0\n")
+	git("add", "go.mod")
+	git("commit", "-q", "-m", "Initial commit")
+	writeGoMod("module github.com/example/service\n\ngo 1.21\n\nrequire github.com/pkg/errors v0.9.1\n")
+
+	info, err := ParseGoModAtRevision(projectDir, "HEAD")
+	if err != nil {
+		t.Fatalf("ParseGoModAtRevision() error = %v", err)
+	}
+	expected := []Dependency{{Path: "github.com/pkg/errors", Version: "v0.9.0", Line: 5}}
+	if info.ModuleName != "github.com/example/service" || !reflect.DeepEqual(info.Requires, expected) {
+		t.Errorf("Expected the committed go.mod, got %+v", info)
+	}
+
+	_, err = ParseGoModAtRevision(projectDir, "no-such-branch")
+	if err == nil || !strings.Contains(err.Error(), "failed to read go.mod at no-such-branch") {
+		t.Errorf("Expected an error for an unknown revision, got %v", err)
+	}
+
+	// Revisions are never passed to git as options
+	output := filepath.Join(t.TempDir(), "pwned")
+	_, err = ParseGoModAtRevision(projectDir, "--output="+output)
+	if err == nil || !strings.Contains(err.Error(), "invalid git revision") {
+		t.Errorf("Expected an error for a revision starting with a dash, got %v", err)
+	}
+	if _, statErr := os.Stat(output + ":./go.mod"); statErr == nil {
+		t.Errorf("git was run with the revision as an option")
+	}
+}
@@ -0,0 +1,68 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBitbucketAPI is the base URL of the Bitbucket Cloud REST API
+const DefaultBitbucketAPI = "https://api.bitbucket.org/2.0"
+
+// bitbucketForge checks repositories through the Bitbucket Cloud REST API.
+// Bitbucket has no notion of archived repositories, so only activity is reported.
+type bitbucketForge struct {
+	host    string
+	baseURL string
+	token   string
+}
+
+// bitbucketRepo is the subset of the Bitbucket repository response we use
+type bitbucketRepo struct {
+	FullName   string    `json:"full_name"`
+	UpdatedOn  time.Time `json:"updated_on"`
+	MainBranch struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+func (f *bitbucketForge) Name() string { return "Bitbucket" }
+
+func (f *bitbucketForge) Host() string { return f.host }
+
+func (f *bitbucketForge) APIURL() string { return f.baseURL }
+
+func (f *bitbucketForge) Check(ctx context.Context, get GetFunc, project string) (*RepoInfo, error) {
+	header := http.Header{}
+	if f.token != "" {
+		header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	var data bitbucketRepo
+	found, err := getJSON(ctx, get, fmt.Sprintf("%s/repositories/%s", f.baseURL, project), header, &data)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	owner, repo := splitProject(project)
+	info := &RepoInfo{
+		Forge:         f.Name(),
+		Owner:         owner,
+		Repo:          repo,
+		URL:           data.Links.HTML.Href,
+		DefaultBranch: data.MainBranch.Name,
+		LastActivity:  data.UpdatedOn,
+	}
+	if data.FullName != "" && !strings.EqualFold(data.FullName, project) {
+		info.MovedTo = data.FullName
+	}
+
+	return info, nil
+}
@@ -0,0 +1,179 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+)
+
+// DefaultCacheTTL is how long cached responses are used before they are fetched again
+const DefaultCacheTTL = 24 * time.Hour
+
+// Cache keeps lookup responses on disk, keyed by source and module path, so that
+// repeated runs don't fetch them again. Entries are written atomically, so several
+// godeping processes can share a cache directory.
+type Cache struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool      // Ignore existing entries, but still store new ones
+	created time.Time // Entries stored after this were fetched by this run
+
+	mu   sync.Mutex
+	hits map[string]bool // Module paths with at least one response from an earlier run
+}
+
+// cacheEntry is a single cached response
+type cacheEntry struct {
+	Source     string    `json:"source"`
+	ModulePath string    `json:"module_path"`
+	Resource   string    `json:"resource"`
+	StatusCode int       `json:"status_code"`
+	Body       []byte    `json:"body"`
+	Fetched    time.Time `json:"fetched"`
+}
+
+// DefaultCacheDir returns the godeping directory under the user's cache directory
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "godeping"), nil
+}
+
+// NewCache returns a cache storing its entries under dir for the given time to live
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, created: time.Now()}
+}
+
+// SetRefresh makes the cache ignore existing entries while still storing fresh responses
+func (c *Cache) SetRefresh(refresh bool) {
+	c.refresh = refresh
+}
+
+// path returns the file holding the entry for a resource of a module from a source,
+// e.g. <dir>/proxy.golang.org/github.com/!burnt!sushi/toml/@v/list.json
+func (c *Cache) path(source, modPath, resource string) string {
+	escaped, err := module.EscapePath(modPath)
+	if err != nil {
+		escaped = modPath
+	}
+	source = strings.NewReplacer(":", "_", "/", "_").Replace(source)
+	return filepath.Join(c.dir, source, filepath.FromSlash(escaped), filepath.FromSlash(resource)+".json")
+}
+
+// get returns the cached response for a resource if there is one younger than the TTL
+func (c *Cache) get(source, modPath, resource string) (*cacheEntry, bool) {
+	if c.refresh {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(source, modPath, resource))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || time.Since(entry.Fetched) > c.ttl {
+		return nil, false
+	}
+
+	if entry.Fetched.Before(c.created) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.hits == nil {
+			c.hits = make(map[string]bool)
+		}
+		c.hits[modPath] = true
+	}
+	return &entry, true
+}
+
+// put stores a response, writing it to a temporary file first and renaming it into
+// place so that concurrent readers never see a partial entry
+func (c *Cache) put(entry *cacheEntry) error {
+	path := c.path(entry.Source, entry.ModulePath, entry.Resource)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// served reports whether any response for modPath came from a cache entry stored by an earlier run
+func (c *Cache) served(modPath string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits[modPath]
+}
+
+// cachedGet fetches target, a resource of modPath served by source, through the cache
+// if one is set, sending the given request headers. Only definitive answers (200, 404
+// and 410) are stored.
+func (c *Client) cachedGet(ctx context.Context, source, modPath, resource, target string, header http.Header) (int, []byte, error) {
+	if c.cache != nil {
+		if entry, ok := c.cache.get(source, modPath, resource); ok {
+			return entry.StatusCode, entry.Body, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNotFound, http.StatusGone:
+		if c.cache != nil {
+			// A failed write only costs a fetch on the next run
+			_ = c.cache.put(&cacheEntry{
+				Source:     source,
+				ModulePath: modPath,
+				Resource:   resource,
+				StatusCode: resp.StatusCode,
+				Body:       body,
+				Fetched:    time.Now(),
+			})
+		}
+	}
+
+	return resp.StatusCode, body, nil
+}
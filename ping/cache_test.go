@@ -0,0 +1,198 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheGetPut(t *testing.T) {
+	cache := NewCache(t.TempDir(), time.Hour)
+
+	_, ok := cache.get("proxy.golang.org", "github.com/BurntSushi/toml", "@v/list")
+	assert.False(t, ok)
+
+	assert.NoError(t, cache.put(&cacheEntry{
+		Source:     "proxy.golang.org",
+		ModulePath: "github.com/BurntSushi/toml",
+		Resource:   "@v/list",
+		StatusCode: http.StatusOK,
+		Body:       []byte("v1.0.0\n"),
+		Fetched:    time.Now().Add(-time.Minute),
+	}))
+	assert.FileExists(t, filepath.Join(cache.dir, "proxy.golang.org", "github.com", "!burnt!sushi", "toml", "@v", "list.json"))
+
+	entry, ok := cache.get("proxy.golang.org", "github.com/BurntSushi/toml", "@v/list")
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0\n", string(entry.Body))
+	assert.True(t, cache.served("github.com/BurntSushi/toml"))
+
+	// Entries from other sources are kept apart
+	_, ok = cache.get("goproxy.example.com", "github.com/BurntSushi/toml", "@v/list")
+	assert.False(t, ok)
+
+	// No temporary files are left behind
+	files, err := os.ReadDir(filepath.Join(cache.dir, "proxy.golang.org", "github.com", "!burnt!sushi", "toml", "@v"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	cache.SetRefresh(true)
+	_, ok = cache.get("proxy.golang.org", "github.com/BurntSushi/toml", "@v/list")
+	assert.False(t, ok)
+}
+
+func TestCacheExpiry(t *testing.T) {
+	cache := NewCache(t.TempDir(), time.Hour)
+	assert.NoError(t, cache.put(&cacheEntry{
+		Source:     "pkg.go.dev",
+		ModulePath: "github.com/old/entry",
+		Resource:   "page",
+		StatusCode: http.StatusOK,
+		Fetched:    time.Now().Add(-2 * time.Hour),
+	}))
+
+	_, ok := cache.get("pkg.go.dev", "github.com/old/entry", "page")
+	assert.False(t, ok)
+}
+
+func TestCacheConcurrentWrites(t *testing.T) {
+	cache := NewCache(t.TempDir(), time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cache.put(&cacheEntry{
+				Source:     "proxy.golang.org",
+				ModulePath: "github.com/busy/module",
+				Resource:   "@v/list",
+				StatusCode: http.StatusOK,
+				Body:       []byte("v1.0.0\n"),
+				Fetched:    time.Now(),
+			}))
+		}()
+	}
+	wg.Wait()
+
+	entry, ok := cache.get("proxy.golang.org", "github.com/busy/module", "@v/list")
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0\n", string(entry.Body))
+}
+
+func TestPingPackageWithCache(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/@v/list":        "v1.0.0\n",
+		"github.com/example/lib/@v/v1.0.0.info": `{"Version":"v1.0.0","Time":"` + recent + `"}`,
+		"github.com/example/lib/@v/v1.0.0.mod":  "module github.com/example/lib\n",
+	})
+	cacheDir := t.TempDir()
+
+	run := func(cache *Cache) (RepoStatus, string) {
+		client := NewClient()
+		assert.NoError(t, client.SetGoProxy(proxy.URL))
+		client.SetCache(cache)
+		var progress string
+		client.SetProgressCallback(func(dependency string, status string) {
+			progress = status
+		})
+		results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib", Version: "v1.0.0"}})
+		assert.Len(t, results, 1)
+		return results[0], progress
+	}
+
+	first, progress := run(NewCache(cacheDir, time.Hour))
+	assert.False(t, first.Cached)
+	assert.NotContains(t, progress, "cached")
+	fetched := proxy.requests()
+	assert.Greater(t, fetched, int32(0))
+
+	// A second run, as another process would do, is answered from disk
+	second, progress := run(NewCache(cacheDir, time.Hour))
+	assert.True(t, second.Cached)
+	assert.Contains(t, progress, ", cached)")
+	assert.Equal(t, first.LastPublished, second.LastPublished)
+	assert.Equal(t, fetched, proxy.requests())
+
+	// Refreshing fetches everything again
+	refresh := NewCache(cacheDir, time.Hour)
+	refresh.SetRefresh(true)
+	third, _ := run(refresh)
+	assert.False(t, third.Cached)
+	refetched := proxy.requests()
+	assert.GreaterOrEqual(t, refetched, 2*fetched)
+
+	// Without a cache nothing is stored or read
+	fourth, _ := run(nil)
+	assert.False(t, fourth.Cached)
+	assert.Equal(t, 2*refetched-fetched, proxy.requests())
+}
+
+func TestPingPackageCachesRepoLookups(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"go.uber.org/zap/@v/list":         "v1.27.0\n",
+		"go.uber.org/zap/@v/v1.27.0.info": `{"Version":"v1.27.0","Time":"` + recent + `"}`,
+	})
+	vanity, vanityRequests := newTestVanityServer(t, map[string]string{
+		"go.uber.org/zap": `<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap">`,
+	})
+	api := newTestForgeAPI(t, map[string]string{
+		"/repos/uber-go/zap": `{"full_name":"uber-go/zap","archived":true}`,
+	}, nil)
+	otherAPI := newTestForgeAPI(t, map[string]string{
+		"/repos/uber-go/zap": `{"full_name":"uber-go/zap","archived":false}`,
+	}, nil)
+	cacheDir := t.TempDir()
+
+	var apiRequests int32
+	run := func(api *httptest.Server) RepoStatus {
+		client := NewClient()
+		assert.NoError(t, client.SetGoProxy(proxy.URL))
+		client.SetCache(NewCache(cacheDir, time.Hour))
+		client.SetDiscoverRepos(true)
+		forge, err := NewForge("github", "github.com", api.URL, "")
+		assert.NoError(t, err)
+		client.AddForge(forge)
+		client.SetProgressCallback(func(dependency string, status string) {})
+		client.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("go-get") == "1" {
+				return redirectTransport(vanity).RoundTrip(req)
+			}
+			if req.URL.Host == api.Listener.Addr().String() {
+				atomic.AddInt32(&apiRequests, 1)
+			}
+			return http.DefaultTransport.RoundTrip(req)
+		})
+
+		results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "go.uber.org/zap"}})
+		assert.Len(t, results, 1)
+		return results[0]
+	}
+
+	first := run(api)
+	assert.Equal(t, StatusArchived, first.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(vanityRequests))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&apiRequests))
+
+	// Discovery and the forge answer come from disk on the next run
+	second := run(api)
+	assert.Equal(t, StatusArchived, second.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(vanityRequests))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&apiRequests))
+
+	// Answers from another API endpoint of the forge are not reused
+	third := run(otherAPI)
+	assert.Equal(t, StatusActive, third.Status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&apiRequests))
+}
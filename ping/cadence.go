@@ -0,0 +1,123 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// minCadenceReleases is the number of releases needed to judge a module's silence against its own history
+	minCadenceReleases = 4
+
+	// maxCadenceVersions bounds how many versions of a module have their timestamp fetched, newest first
+	maxCadenceVersions = 100
+
+	// quietGapFactor is how many median gaps a silence must last, on top of exceeding the
+	// longest gap so far, to be anomalous
+	quietGapFactor = 4
+)
+
+// Cadence summarises the release history of a module
+type Cadence struct {
+	Releases        int       `json:"releases"` // Versions with a known publish time
+	FirstRelease    time.Time `json:"first_release"`
+	LastRelease     time.Time `json:"last_release"`
+	ReleasesPerYear float64   `json:"releases_per_year"` // Since the first release
+	MedianGapDays   float64   `json:"median_gap_days"`
+	LongestGapDays  float64   `json:"longest_gap_days"`
+	SilenceDays     float64   `json:"silence_days"` // Time since the last release
+	Anomalous       bool      `json:"anomalous"`    // The silence is unusually long for this module
+}
+
+// Judged reports whether there are enough releases to tell if the silence is anomalous
+func (c *Cadence) Judged() bool {
+	return c.Releases >= minCadenceReleases
+}
+
+// String summarises the cadence in a sentence fragment
+func (c *Cadence) String() string {
+	return fmt.Sprintf("%d releases, %.1f per year, median gap %.0f days, longest gap %.0f days, quiet for %.0f days",
+		c.Releases, c.ReleasesPerYear, c.MedianGapDays, c.LongestGapDays, c.SilenceDays)
+}
+
+// SetCadence enables fetching the publish time of every version of each module to work out its
+// release cadence. Staleness is then judged against the module's own history when it has enough
+// releases, falling back to the unmaintained duration otherwise.
+func (c *Client) SetCadence(enabled bool) {
+	c.cadence = enabled
+}
+
+// releaseHistory fetches the publish time of the newest versions of a module from a proxy and
+// summarises them. Versions the proxy has no metadata for are skipped.
+func (c *Client) releaseHistory(ctx context.Context, proxyURL, modPath string, versions []string) (*Cadence, error) {
+	var tagged []string
+	for _, v := range versions {
+		if semver.IsValid(v) {
+			tagged = append(tagged, v)
+		}
+	}
+	sort.Slice(tagged, func(i, j int) bool { return semver.Compare(tagged[i], tagged[j]) > 0 })
+	if len(tagged) > maxCadenceVersions {
+		tagged = tagged[:maxCadenceVersions]
+	}
+
+	var times []time.Time
+	for _, v := range tagged {
+		info, err := c.infoFromProxy(ctx, proxyURL, modPath, v)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !info.Time.IsZero() {
+			times = append(times, info.Time)
+		}
+	}
+	if len(times) == 0 {
+		return nil, nil
+	}
+	return summariseCadence(times, time.Now()), nil
+}
+
+// summariseCadence computes the release statistics of the publish times of a module's versions
+func summariseCadence(times []time.Time, now time.Time) *Cadence {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	days := func(d time.Duration) float64 { return d.Hours() / 24 }
+	cadence := &Cadence{
+		Releases:     len(times),
+		FirstRelease: times[0],
+		LastRelease:  times[len(times)-1],
+		SilenceDays:  days(now.Sub(times[len(times)-1])),
+	}
+
+	lifetime := math.Max(days(now.Sub(times[0])), 1)
+	cadence.ReleasesPerYear = float64(len(times)) * 365 / lifetime
+
+	var gaps []float64
+	for i := 1; i < len(times); i++ {
+		gaps = append(gaps, days(times[i].Sub(times[i-1])))
+	}
+	if len(gaps) == 0 {
+		return cadence
+	}
+	sort.Float64s(gaps)
+	cadence.LongestGapDays = gaps[len(gaps)-1]
+	if len(gaps)%2 == 1 {
+		cadence.MedianGapDays = gaps[len(gaps)/2]
+	} else {
+		cadence.MedianGapDays = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+	}
+
+	// Quieter than ever before, and well beyond the usual rhythm
+	cadence.Anomalous = cadence.Judged() &&
+		cadence.SilenceDays > cadence.LongestGapDays &&
+		cadence.SilenceDays > quietGapFactor*cadence.MedianGapDays
+	return cadence
+}
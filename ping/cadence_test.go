@@ -0,0 +1,110 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummariseCadence(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// Releases 10, 20 and 40 days apart, the last one 100 days ago
+	last := now.Add(-100 * day)
+	times := []time.Time{last, last.Add(-40 * day), last.Add(-60 * day), last.Add(-70 * day)}
+	cadence := summariseCadence(times, now)
+	assert.Equal(t, 4, cadence.Releases)
+	assert.Equal(t, last.Add(-70*day), cadence.FirstRelease)
+	assert.Equal(t, last, cadence.LastRelease)
+	assert.Equal(t, 20.0, cadence.MedianGapDays)
+	assert.Equal(t, 40.0, cadence.LongestGapDays)
+	assert.Equal(t, 100.0, cadence.SilenceDays)
+	assert.InDelta(t, 4*365/170.0, cadence.ReleasesPerYear, 0.001)
+	assert.True(t, cadence.Judged())
+	assert.True(t, cadence.Anomalous)
+
+	// Not longer than the longest gap
+	cadence = summariseCadence([]time.Time{now.Add(-30 * day), now.Add(-230 * day), now.Add(-240 * day), now.Add(-250 * day)}, now)
+	assert.Equal(t, 10.0, cadence.MedianGapDays)
+	assert.False(t, cadence.Anomalous)
+
+	// Too few releases to tell
+	cadence = summariseCadence([]time.Time{now.Add(-1000 * day), now.Add(-1001 * day)}, now)
+	assert.False(t, cadence.Judged())
+	assert.False(t, cadence.Anomalous)
+	assert.Equal(t, 1.0, cadence.MedianGapDays)
+
+	cadence = summariseCadence([]time.Time{now.Add(-10 * day)}, now)
+	assert.Equal(t, 0.0, cadence.LongestGapDays)
+	assert.False(t, cadence.Anomalous)
+}
+
+func TestPingPackageCadence(t *testing.T) {
+	files := map[string]string{}
+	addRelease := func(modPath, version string, published time.Time) {
+		files[modPath+"/@v/list"] += version + "\n"
+		files[modPath+"/@v/"+version+".info"] = fmt.Sprintf(`{"Version":%q,"Time":%q}`, version, published.UTC().Format(time.RFC3339))
+	}
+
+	// Released weekly, then went silent six months ago
+	silent := time.Now().AddDate(0, -6, 0)
+	for i := 0; i < 8; i++ {
+		addRelease("github.com/example/busy", fmt.Sprintf("v1.%d.0", i), silent.AddDate(0, 0, -7*(7-i)))
+	}
+	// A finished library that has always released every few years
+	finished := time.Now().AddDate(-2, -6, 0)
+	for i := 0; i < 5; i++ {
+		addRelease("github.com/example/finished", fmt.Sprintf("v1.0.%d", i), finished.AddDate(-3*(4-i), 0, 0))
+	}
+	// Too little history, so the unmaintained duration applies. Versions without metadata are skipped.
+	addRelease("github.com/example/young", "v0.1.0", time.Now().AddDate(-3, 0, 0))
+	files["github.com/example/young/@v/list"] += "v0.2.0-rc.1\n"
+
+	proxy := newTestProxy(t, files)
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetCadence(true)
+	client.SetRateLimit(0)
+	progress := make(map[string]string)
+	var mu sync.Mutex
+	client.SetProgressCallback(func(dependency string, status string) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress[dependency] = status
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/busy", Version: "v1.7.0"},
+		{Path: "github.com/example/finished", Version: "v1.0.4"},
+		{Path: "github.com/example/young", Version: "v0.1.0"},
+	})
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	busy := byPath["github.com/example/busy"]
+	assert.Equal(t, StatusStale, busy.Status)
+	assert.Equal(t, 8, busy.Cadence.Releases)
+	assert.True(t, busy.Cadence.Anomalous)
+	assert.Contains(t, busy.Reason, "Quiet for ")
+	assert.Contains(t, progress["github.com/example/busy"], ", unusually quiet")
+	assert.Contains(t, busy.Evidence, Evidence{Source: proxyHost(proxy.URL), Signal: SignalCadence, Value: busy.Cadence.String(), Timestamp: busy.Cadence.LastRelease})
+	assert.Less(t, busy.Health.Signal(HealthCadence).Score, 10)
+
+	finishedStatus := byPath["github.com/example/finished"]
+	assert.Equal(t, StatusActive, finishedStatus.Status)
+	assert.False(t, finishedStatus.Cadence.Anomalous)
+	assert.Equal(t, 100, finishedStatus.Health.Signal(HealthCadence).Score)
+
+	young := byPath["github.com/example/young"]
+	assert.Equal(t, StatusStale, young.Status)
+	assert.Equal(t, 1, young.Cadence.Releases)
+	assert.Contains(t, young.Reason, "Not updated since")
+}
@@ -0,0 +1,105 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingPackageDeadline(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+
+	// The fast module answers right away, every other one hangs until the client gives up
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/") {
+		case "github.com/example/fast/@v/list":
+			w.Write([]byte("v1.0.0\n"))
+		case "github.com/example/fast/@v/v1.0.0.info":
+			w.Write([]byte(`{"Version":"v1.0.0","Time":"` + recent + `"}`))
+		default:
+			if strings.HasPrefix(r.URL.Path, "/github.com/example/fast") {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(proxy.Close)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	// More dependencies than concurrent checks, so some are still waiting for a slot when time runs out
+	deps := []parser.Dependency{{Path: "github.com/example/fast", Version: "v1.0.0"}}
+	for i := 0; i < 15; i++ {
+		deps = append(deps, parser.Dependency{Path: fmt.Sprintf("github.com/example/slow%d", i), Version: "v1.0.0"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results := client.PingPackage(ctx, deps)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Len(t, results, len(deps))
+
+	for _, result := range results {
+		if result.ModulePath == "github.com/example/fast" {
+			// Finished checks keep their verdict, unless the fast one was stuck behind slow ones
+			if !result.Incomplete {
+				assert.Empty(t, result.Error)
+			}
+			continue
+		}
+		assert.True(t, result.Incomplete, result.ModulePath)
+		assert.Equal(t, StatusUnknown, result.Status, result.ModulePath)
+		assert.Equal(t, context.DeadlineExceeded.Error(), result.Error)
+	}
+}
+
+func TestPingPackageCancelled(t *testing.T) {
+	proxy := newTestProxy(t, nil)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	var progress []string
+	client.SetProgressCallback(func(dependency string, status string) {
+		progress = append(progress, status)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := client.PingPackage(ctx, []parser.Dependency{{Path: "github.com/example/lib"}})
+	assert.Len(t, results, 1)
+	assert.True(t, results[0].Incomplete)
+	assert.Equal(t, "Not checked: context canceled", results[0].Reason)
+	assert.Equal(t, []string{"Incomplete (context canceled)"}, progress)
+	assert.Zero(t, proxy.requests())
+}
+
+func TestSetRequestTimeout(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(proxy.Close)
+
+	client := NewClient()
+	client.SetRetryPolicy(RetryPolicy{})
+	client.SetRequestTimeout(50 * time.Millisecond)
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib"}})
+	assert.Len(t, results, 1)
+	assert.False(t, results[0].Incomplete)
+	assert.Contains(t, results[0].Error, "Client.Timeout exceeded")
+}
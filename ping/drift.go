@@ -0,0 +1,89 @@
+package ping
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Kinds of version drift between the required and the latest version of a module
+const (
+	DriftNone  = "none"
+	DriftPatch = "patch"
+	DriftMinor = "minor"
+	DriftMajor = "major"
+)
+
+// maxMajorProbes bounds how many successive /vN module paths are probed for newer major versions
+const maxMajorProbes = 20
+
+// checkDrift fills in how far the required version of dep is behind the latest release,
+// including newer major versions published under /vN module paths, and when the required
+// version was published. Lookup failures leave the fields empty.
+func (c *Client) checkDrift(ctx context.Context, proxyURL string, dep parser.Dependency, status *RepoStatus) {
+	if info, err := c.infoFromProxy(ctx, proxyURL, dep.Path, dep.Version); err == nil {
+		status.VersionPublished = info.Time
+	}
+
+	latestMajorPath, latestMajor, err := c.latestMajorFromProxy(ctx, proxyURL, dep.Path)
+	if err == nil && latestMajorPath != "" {
+		status.LatestMajorPath = latestMajorPath
+		status.LatestMajorVersion = latestMajor.Version
+	}
+
+	status.Drift = classifyDrift(dep.Version, status.LatestVersion, status.LatestMajorPath != "")
+}
+
+// latestMajorFromProxy probes <prefix>/vN module paths above the major version of modPath
+// and returns the highest one that exists along with its latest version
+func (c *Client) latestMajorFromProxy(ctx context.Context, proxyURL, modPath string) (string, ProxyInfo, error) {
+	prefix, pathMajor, ok := module.SplitPathVersion(modPath)
+	if !ok || strings.HasPrefix(pathMajor, ".") {
+		// gopkg.in paths encode the major version differently and are not probed
+		return "", ProxyInfo{}, nil
+	}
+
+	major := 1
+	if pathMajor != "" {
+		major, _ = strconv.Atoi(strings.TrimPrefix(pathMajor, "/v"))
+	}
+
+	var latestPath string
+	var latest ProxyInfo
+	for n := major + 1; n <= major+maxMajorProbes; n++ {
+		path := prefix + "/v" + strconv.Itoa(n)
+		info, _, err := c.latestFromProxy(ctx, proxyURL, path)
+		if isNotFound(err) {
+			break
+		}
+		if err != nil {
+			return "", ProxyInfo{}, err
+		}
+		latestPath, latest = path, info
+	}
+
+	return latestPath, latest, nil
+}
+
+// classifyDrift compares the required version with the latest version of the same module path
+func classifyDrift(current, latest string, newerMajorPath bool) string {
+	if newerMajorPath {
+		return DriftMajor
+	}
+	if !semver.IsValid(current) || !semver.IsValid(latest) || semver.Compare(current, latest) >= 0 {
+		return DriftNone
+	}
+
+	switch {
+	case semver.Major(current) != semver.Major(latest):
+		return DriftMajor
+	case semver.MajorMinor(current) != semver.MajorMinor(latest):
+		return DriftMinor
+	default:
+		return DriftPatch
+	}
+}
@@ -0,0 +1,96 @@
+package ping
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyDrift(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        string
+		latest         string
+		newerMajorPath bool
+		expected       string
+	}{
+		{name: "Up to date", current: "v1.2.3", latest: "v1.2.3", expected: DriftNone},
+		{name: "Ahead of latest release", current: "v1.3.0-rc.1", latest: "v1.2.3", expected: DriftNone},
+		{name: "Patch behind", current: "v1.2.1", latest: "v1.2.3", expected: DriftPatch},
+		{name: "Minor behind", current: "v1.0.9", latest: "v1.2.0", expected: DriftMinor},
+		{name: "v0 to v1 on the same path", current: "v0.9.0", latest: "v1.0.0", expected: DriftMajor},
+		{name: "Newer major path", current: "v1.2.3", latest: "v1.2.3", newerMajorPath: true, expected: DriftMajor},
+		{name: "Pseudo-version behind release", current: "v0.0.0-20200101000000-abcdef123456", latest: "v0.0.1", expected: DriftPatch},
+		{name: "Unknown latest", current: "v1.0.0", latest: "", expected: DriftNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyDrift(tt.current, tt.latest, tt.newerMajorPath))
+		})
+	}
+}
+
+func TestLatestMajorFromProxy(t *testing.T) {
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/v2/@v/list":        "v2.0.0\nv2.1.0\n",
+		"github.com/example/lib/v2/@v/v2.1.0.info": `{"Version":"v2.1.0","Time":"2023-01-01T00:00:00Z"}`,
+		"github.com/example/lib/v3/@v/list":        "v3.0.0\n",
+		"github.com/example/lib/v3/@v/v3.0.0.info": `{"Version":"v3.0.0","Time":"2024-01-01T00:00:00Z"}`,
+	})
+	client := NewClient()
+
+	path, info, err := client.latestMajorFromProxy(context.Background(), proxy.URL, "github.com/example/lib")
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/example/lib/v3", path)
+	assert.Equal(t, "v3.0.0", info.Version)
+
+	path, _, err = client.latestMajorFromProxy(context.Background(), proxy.URL, "github.com/example/lib/v3")
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+
+	path, _, err = client.latestMajorFromProxy(context.Background(), proxy.URL, "gopkg.in/yaml.v2")
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+}
+
+func TestPingPackageVersionDrift(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/@v/list":            "v1.0.0\nv1.4.2\n",
+		"github.com/example/lib/@v/v1.0.0.info":     `{"Version":"v1.0.0","Time":"2021-06-01T00:00:00Z"}`,
+		"github.com/example/lib/@v/v1.4.2.info":     `{"Version":"v1.4.2","Time":"` + recent + `"}`,
+		"github.com/example/lib/v2/@v/list":         "v2.3.0\n",
+		"github.com/example/lib/v2/@v/v2.3.0.info":  `{"Version":"v2.3.0","Time":"` + recent + `"}`,
+		"github.com/example/current/@v/list":        "v0.5.1\n",
+		"github.com/example/current/@v/v0.5.1.info": `{"Version":"v0.5.1","Time":"` + recent + `"}`,
+	})
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/lib", Version: "v1.0.0"},
+		{Path: "github.com/example/current", Version: "v0.5.1"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	lib := byPath["github.com/example/lib"]
+	assert.Equal(t, "v1.4.2", lib.LatestVersion)
+	assert.Equal(t, "github.com/example/lib/v2", lib.LatestMajorPath)
+	assert.Equal(t, "v2.3.0", lib.LatestMajorVersion)
+	assert.Equal(t, DriftMajor, lib.Drift)
+	assert.Equal(t, time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC), lib.VersionPublished)
+
+	current := byPath["github.com/example/current"]
+	assert.Equal(t, DriftNone, current.Drift)
+	assert.Empty(t, current.LatestMajorPath)
+}
@@ -0,0 +1,43 @@
+package ping
+
+import "time"
+
+// Kinds of evidence a status can be based on
+const (
+	SignalLatestRelease = "latest_release"  // Latest version known to a module proxy, and when it was published
+	SignalNotFound      = "not_found"       // The lookup source doesn't know the module
+	SignalLookupError   = "lookup_error"    // A lookup failed, Value holds the error
+	SignalPrivate       = "private"         // The module matched the private module patterns
+	SignalNotCached     = "not_cached"      // Offline mode found nothing in the module cache
+	SignalDeprecated    = "deprecated"      // Deprecation message from the latest go.mod
+	SignalRetracted     = "retracted"       // The required version lies in a retracted range
+	SignalNewerVersion  = "newer_version"   // A newer release or major version exists
+	SignalPseudoVersion = "pseudo_version"  // Commit time encoded in the required pseudo-version
+	SignalVulnerability = "vulnerability"   // A known vulnerability affects the required version
+	SignalCadence       = "release_cadence" // Summary of the release history
+	SignalRepoRoot      = "repo_root"       // Repository the module path resolves to
+	SignalRepoArchived  = "repo_archived"   // The forge reports the repository as archived
+	SignalRepoDisabled  = "repo_disabled"   // The forge reports the repository as disabled
+	SignalRepoMoved     = "repo_moved"      // The repository was renamed or transferred
+	SignalRepoActivity  = "repo_activity"   // Last activity on the repository
+)
+
+// Evidence is a single observation a dependency's status is based on
+type Evidence struct {
+	Source    string    `json:"source"`    // Where it was observed, e.g. "proxy.golang.org" or "GitHub"
+	Signal    string    `json:"signal"`    // One of the Signal constants
+	Value     string    `json:"value"`     // What was observed
+	Timestamp time.Time `json:"timestamp"` // When the observed event happened, if known
+	URL       string    `json:"url,omitempty"`
+}
+
+// addEvidence records an observation on the status
+func (s *RepoStatus) addEvidence(source, signal, value string, timestamp time.Time, url string) {
+	s.Evidence = append(s.Evidence, Evidence{
+		Source:    source,
+		Signal:    signal,
+		Value:     value,
+		Timestamp: timestamp,
+		URL:       url,
+	})
+}
@@ -0,0 +1,83 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingPackageEvidence(t *testing.T) {
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/old/lib/@v/list":        "v1.0.0\nv1.1.0\n",
+		"github.com/old/lib/@v/v1.1.0.info": `{"Version":"v1.1.0","Time":"2020-05-01T00:00:00Z"}`,
+		"github.com/old/lib/@v/v1.0.0.info": `{"Version":"v1.0.0","Time":"2019-05-01T00:00:00Z"}`,
+		"github.com/old/lib/@v/v1.1.0.mod":  "// Deprecated: use github.com/new/lib instead.\nmodule github.com/old/lib\n\nretract v1.0.0 // Leaks memory.\n",
+	})
+	api := newTestForgeAPI(t, map[string]string{
+		"/repos/old/lib": `{"full_name":"old/lib","html_url":"https://github.com/old/lib","archived":true,"default_branch":"main","pushed_at":"2020-06-01T00:00:00Z"}`,
+	}, nil)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	forge, err := NewForge("github", "github.com", api.URL, "")
+	assert.NoError(t, err)
+	client.AddForge(forge)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/old/lib", Version: "v1.0.0"}})
+	assert.Len(t, results, 1)
+
+	host := proxyHost(proxy.URL)
+	assert.Equal(t, []Evidence{
+		{Source: host, Signal: SignalLatestRelease, Value: "v1.1.0", Timestamp: time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC), URL: proxy.URL + "/github.com/old/lib/@v/v1.1.0.info"},
+		{Source: host, Signal: SignalDeprecated, Value: "use github.com/new/lib instead.", Timestamp: time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC), URL: proxy.URL + "/github.com/old/lib/@v/v1.1.0.mod"},
+		{Source: host, Signal: SignalRetracted, Value: "[v1.0.0, v1.0.0] Leaks memory.", URL: proxy.URL + "/github.com/old/lib/@v/v1.1.0.mod"},
+		{Source: host, Signal: SignalNewerVersion, Value: "v1.1.0 (minor)", Timestamp: time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{Source: "module path", Signal: SignalRepoRoot, Value: "https://github.com/old/lib"},
+		{Source: "GitHub", Signal: SignalRepoArchived, Value: "archived", URL: "https://github.com/old/lib"},
+		{Source: "GitHub", Signal: SignalRepoActivity, Value: "main", Timestamp: time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC), URL: "https://github.com/old/lib"},
+	}, results[0].Evidence)
+
+	// Everything behind the verdict is part of the JSON output
+	data, err := json.Marshal(results[0])
+	assert.NoError(t, err)
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "archived", decoded["status"])
+	assert.Equal(t, float64(200), decoded["status_code"])
+	assert.Equal(t, "Repository archived on GitHub", decoded["reason"])
+	assert.Len(t, decoded["evidence"], 7)
+}
+
+func TestPingPackageErrorEvidence(t *testing.T) {
+	broken := newFailingProxy(t, 500)
+
+	client := NewClient()
+	client.SetRetryPolicy(RetryPolicy{})
+	assert.NoError(t, client.SetGoProxy(broken.URL))
+	client.SetPrivatePatterns(PrivatePatterns{Private: "corp.example.com", NoProxy: "corp.example.com"})
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/lib", Version: "v0.0.0-20190102030405-abcdef123456"},
+		{Path: "corp.example.com/lib"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	lib := byPath["github.com/example/lib"].Evidence
+	assert.Len(t, lib, 3)
+	assert.Equal(t, SignalLookupError, lib[0].Signal)
+	assert.Contains(t, lib[0].Value, "unexpected status 500")
+	assert.Equal(t, Evidence{Source: "go.mod", Signal: SignalPseudoVersion, Value: "v0.0.0-20190102030405-abcdef123456", Timestamp: time.Date(2019, time.January, 2, 3, 4, 5, 0, time.UTC)}, lib[1])
+	assert.Equal(t, SignalRepoRoot, lib[2].Signal)
+
+	assert.Equal(t, []Evidence{{Source: "environment", Signal: SignalPrivate, Value: "matches GOPRIVATE, GONOPROXY or GONOSUMDB"}}, byPath["corp.example.com/lib"].Evidence)
+}
@@ -0,0 +1,145 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Forge looks up repository state on a code hosting service
+type Forge interface {
+	// Name returns the display name of the service, e.g. "GitHub"
+	Name() string
+	// Host returns the host that repositories on this forge live under, e.g. "github.com"
+	Host() string
+	// APIURL returns the base URL of the forge's API, e.g. "https://api.github.com"
+	APIURL() string
+	// Check fetches the state of a project ("owner/repo", or "group/subgroup/repo" on GitLab),
+	// making its requests through get. It returns nil without an error if the project does
+	// not exist or is not visible.
+	Check(ctx context.Context, get GetFunc, project string) (*RepoInfo, error)
+}
+
+// GetFunc fetches url with the given request headers, following redirects, and returns
+// the status code and body of the response. The client passes one that retries, rate
+// limits and caches requests.
+type GetFunc func(ctx context.Context, url string, header http.Header) (statusCode int, body []byte, err error)
+
+// RepoInfo describes a source repository as reported by its hosting service
+type RepoInfo struct {
+	Forge         string
+	Owner         string
+	Repo          string
+	URL           string
+	Archived      bool // Archived or otherwise read-only
+	Disabled      bool
+	MovedTo       string // New project path if the repository was renamed or transferred
+	DefaultBranch string
+	LastActivity  time.Time // Last push, commit or other activity, depending on the forge
+}
+
+// NewForge creates a forge checker of the given kind ("github", "gitlab", "gitea" or "bitbucket")
+// for repositories under host. An empty baseURL selects the API location of the public service,
+// or the conventional API path on host for self-hosted instances.
+func NewForge(kind, host, baseURL, token string) (Forge, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	switch kind {
+	case "github":
+		if baseURL == "" {
+			baseURL = DefaultGitHubAPI
+			if host != "github.com" {
+				baseURL = "https://" + host + "/api/v3"
+			}
+		}
+		return &gitHubForge{host: host, baseURL: baseURL, token: token}, nil
+	case "gitlab":
+		if baseURL == "" {
+			baseURL = "https://" + host + "/api/v4"
+		}
+		return &gitLabForge{host: host, baseURL: baseURL, token: token}, nil
+	case "gitea":
+		if baseURL == "" {
+			baseURL = "https://" + host + "/api/v1"
+		}
+		return &giteaForge{host: host, baseURL: baseURL, token: token}, nil
+	case "bitbucket":
+		if baseURL == "" {
+			baseURL = DefaultBitbucketAPI
+		}
+		return &bitbucketForge{host: host, baseURL: baseURL, token: token}, nil
+	}
+	return nil, fmt.Errorf("unknown forge kind %q (want github, gitlab, gitea or bitbucket)", kind)
+}
+
+// ParseForge parses a forge specification of the form kind:host[=api-url],
+// e.g. "gitlab:gitlab.example.com" or "gitea:git.example.com=https://git.example.com/api/v1"
+func ParseForge(spec, token string) (Forge, error) {
+	kind, rest, ok := strings.Cut(spec, ":")
+	if !ok || rest == "" {
+		return nil, fmt.Errorf("invalid forge %q: want kind:host[=api-url]", spec)
+	}
+	host, baseURL, _ := strings.Cut(rest, "=")
+	return NewForge(kind, host, baseURL, token)
+}
+
+// DefaultForges returns checkers for the public forges godeping knows about.
+// The tokens map is keyed by forge kind.
+func DefaultForges(tokens map[string]string) []Forge {
+	defaults := []struct{ kind, host string }{
+		{"github", "github.com"},
+		{"gitlab", "gitlab.com"},
+		{"gitea", "codeberg.org"},
+		{"gitea", "gitea.com"},
+		{"bitbucket", "bitbucket.org"},
+	}
+
+	forges := make([]Forge, 0, len(defaults))
+	for _, d := range defaults {
+		forge, _ := NewForge(d.kind, d.host, "", tokens[d.kind])
+		forges = append(forges, forge)
+	}
+	return forges
+}
+
+// repoRootFromModulePath splits a host/owner/repo[/vN][/subpkg] module path into the
+// host and the project path on it
+func repoRootFromModulePath(modPath string) (host, project string, ok bool) {
+	parts := strings.Split(modPath, "/")
+	if len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1] + "/" + strings.TrimSuffix(parts[2], ".git"), true
+}
+
+// splitProject splits a project path into its owner (namespace) and repository name
+func splitProject(project string) (owner, repo string) {
+	if i := strings.LastIndex(project, "/"); i >= 0 {
+		return project[:i], project[i+1:]
+	}
+	return "", project
+}
+
+// getJSON fetches url and decodes the JSON response into v.
+// It reports found=false without an error for 404 responses.
+func getJSON(ctx context.Context, get GetFunc, url string, header http.Header, v any) (found bool, err error) {
+	// Renamed and transferred repositories may answer with a redirect, which get follows
+	statusCode, body, err := get(ctx, url, header)
+	if err != nil {
+		return false, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if statusCode != http.StatusOK {
+		return false, fmt.Errorf("%s returned status %d", url, statusCode)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return false, fmt.Errorf("invalid response from %s: %v", url, err)
+	}
+	return true, nil
+}
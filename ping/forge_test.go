@@ -0,0 +1,194 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestForgeAPI starts a forge API stub serving JSON bodies keyed by the escaped request path,
+// or redirecting to the path given as "redirect:<path>", and recording the headers of the last request
+func newTestForgeAPI(t *testing.T, responses map[string]string, lastHeader *http.Header) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lastHeader != nil {
+			*lastHeader = r.Header.Clone()
+		}
+		body, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if target, ok := strings.CutPrefix(body, "redirect:"); ok {
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// clientGet returns a GetFunc making uncached requests with httpClient
+func clientGet(httpClient *http.Client) GetFunc {
+	client := NewClient()
+	client.httpClient = httpClient
+	return func(ctx context.Context, url string, header http.Header) (int, []byte, error) {
+		return client.cachedGet(ctx, "", "", "", url, header)
+	}
+}
+
+func TestRepoRootFromModulePath(t *testing.T) {
+	tests := []struct {
+		modPath string
+		host    string
+		project string
+		ok      bool
+	}{
+		{modPath: "github.com/pkg/errors", host: "github.com", project: "pkg/errors", ok: true},
+		{modPath: "github.com/go-chi/chi/v5", host: "github.com", project: "go-chi/chi", ok: true},
+		{modPath: "gitlab.com/group/project.git/sub", host: "gitlab.com", project: "group/project", ok: true},
+		{modPath: "codeberg.org/user/lib", host: "codeberg.org", project: "user/lib", ok: true},
+		{modPath: "github.com/pkg", ok: false},
+		{modPath: "go.uber.org/zap", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modPath, func(t *testing.T) {
+			host, project, ok := repoRootFromModulePath(tt.modPath)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.host, host)
+			assert.Equal(t, tt.project, project)
+		})
+	}
+}
+
+func TestParseForge(t *testing.T) {
+	forge, err := ParseForge("gitlab:gitlab.example.com", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "gitlab.example.com", forge.Host())
+	assert.Equal(t, "https://gitlab.example.com/api/v4", forge.(*gitLabForge).baseURL)
+
+	forge, err = ParseForge("gitea:git.example.com=http://localhost:3000/api/v1/", "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "git.example.com", forge.Host())
+	assert.Equal(t, "http://localhost:3000/api/v1", forge.(*giteaForge).baseURL)
+
+	forge, err = ParseForge("github:github.example.com", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3", forge.(*gitHubForge).baseURL)
+
+	_, err = ParseForge("sourcehut:git.sr.ht", "")
+	assert.Error(t, err)
+
+	_, err = ParseForge("gitlab", "")
+	assert.Error(t, err)
+}
+
+func TestDefaultForges(t *testing.T) {
+	hosts := []string{}
+	for _, forge := range DefaultForges(nil) {
+		hosts = append(hosts, forge.Host())
+	}
+	assert.Equal(t, []string{"github.com", "gitlab.com", "codeberg.org", "gitea.com", "bitbucket.org"}, hosts)
+}
+
+func TestGitLabForge(t *testing.T) {
+	var header http.Header
+	api := newTestForgeAPI(t, map[string]string{
+		"/projects/group%2Fsub%2Fproject": `{"path_with_namespace":"group/sub/project","web_url":"https://gitlab.com/group/sub/project","archived":true,"default_branch":"master","last_activity_at":"2023-11-02T08:00:00Z"}`,
+		"/projects/old%2Fproject":         `{"path_with_namespace":"new/project"}`,
+	}, &header)
+
+	forge, err := NewForge("gitlab", "gitlab.com", api.URL, "gl-token")
+	assert.NoError(t, err)
+
+	info, err := forge.Check(context.Background(), clientGet(http.DefaultClient), "group/sub/project")
+	assert.NoError(t, err)
+	assert.Equal(t, "gl-token", header.Get("PRIVATE-TOKEN"))
+	assert.Equal(t, &RepoInfo{
+		Forge:         "GitLab",
+		Owner:         "group/sub",
+		Repo:          "project",
+		URL:           "https://gitlab.com/group/sub/project",
+		Archived:      true,
+		DefaultBranch: "master",
+		LastActivity:  time.Date(2023, time.November, 2, 8, 0, 0, 0, time.UTC),
+	}, info)
+
+	info, err = forge.Check(context.Background(), clientGet(http.DefaultClient), "old/project")
+	assert.NoError(t, err)
+	assert.Equal(t, "new/project", info.MovedTo)
+
+	info, err = forge.Check(context.Background(), clientGet(http.DefaultClient), "missing/project")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestGiteaForge(t *testing.T) {
+	var header http.Header
+	api := newTestForgeAPI(t, map[string]string{
+		"/repos/user/lib": `{"full_name":"user/lib","html_url":"https://codeberg.org/user/lib","archived":true,"default_branch":"main","updated_at":"2022-01-15T09:30:00Z"}`,
+	}, &header)
+
+	forge, err := NewForge("gitea", "codeberg.org", api.URL, "gt-token")
+	assert.NoError(t, err)
+
+	info, err := forge.Check(context.Background(), clientGet(http.DefaultClient), "user/lib")
+	assert.NoError(t, err)
+	assert.Equal(t, "token gt-token", header.Get("Authorization"))
+	assert.Equal(t, "Gitea (codeberg.org)", info.Forge)
+	assert.True(t, info.Archived)
+	assert.Equal(t, "main", info.DefaultBranch)
+	assert.Equal(t, "https://codeberg.org/user/lib", info.URL)
+	assert.Equal(t, time.Date(2022, time.January, 15, 9, 30, 0, 0, time.UTC), info.LastActivity)
+}
+
+func TestBitbucketForge(t *testing.T) {
+	api := newTestForgeAPI(t, map[string]string{
+		"/repositories/team/repo": `{"full_name":"team/repo","updated_on":"2021-06-30T18:45:00Z","mainbranch":{"name":"develop"},"links":{"html":{"href":"https://bitbucket.org/team/repo"}}}`,
+	}, nil)
+
+	forge, err := NewForge("bitbucket", "bitbucket.org", api.URL, "")
+	assert.NoError(t, err)
+
+	info, err := forge.Check(context.Background(), clientGet(http.DefaultClient), "team/repo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bitbucket", info.Forge)
+	assert.False(t, info.Archived)
+	assert.Equal(t, "develop", info.DefaultBranch)
+	assert.Equal(t, "https://bitbucket.org/team/repo", info.URL)
+	assert.Equal(t, time.Date(2021, time.June, 30, 18, 45, 0, 0, time.UTC), info.LastActivity)
+}
+
+func TestPingPackageWithSelfHostedForge(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"git.example.com/team/lib/@v/list":        "v1.0.0\n",
+		"git.example.com/team/lib/@v/v1.0.0.info": `{"Version":"v1.0.0","Time":"` + recent + `"}`,
+	})
+	api := newTestForgeAPI(t, map[string]string{
+		"/projects/team%2Flib": `{"path_with_namespace":"team/lib","archived":true,"default_branch":"main"}`,
+	}, nil)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	forge, err := ParseForge("gitlab:git.example.com="+api.URL, "")
+	assert.NoError(t, err)
+	client.AddForge(forge)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "git.example.com/team/lib"}})
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusArchived, results[0].Status)
+	assert.Equal(t, "Repository archived on GitLab", results[0].Reason)
+	assert.Equal(t, "main", results[0].DefaultBranch)
+}
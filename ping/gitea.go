@@ -0,0 +1,61 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// giteaForge checks repositories through the Gitea REST API, which is also served by
+// Forgejo instances such as codeberg.org
+type giteaForge struct {
+	host    string
+	baseURL string
+	token   string
+}
+
+// giteaRepo is the subset of the Gitea "get a repository" response we use
+type giteaRepo struct {
+	FullName      string    `json:"full_name"`
+	HTMLURL       string    `json:"html_url"`
+	Archived      bool      `json:"archived"`
+	DefaultBranch string    `json:"default_branch"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (f *giteaForge) Name() string { return "Gitea (" + f.host + ")" }
+
+func (f *giteaForge) Host() string { return f.host }
+
+func (f *giteaForge) APIURL() string { return f.baseURL }
+
+func (f *giteaForge) Check(ctx context.Context, get GetFunc, project string) (*RepoInfo, error) {
+	header := http.Header{}
+	if f.token != "" {
+		header.Set("Authorization", "token "+f.token)
+	}
+
+	var data giteaRepo
+	found, err := getJSON(ctx, get, fmt.Sprintf("%s/repos/%s", f.baseURL, project), header, &data)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	owner, repo := splitProject(project)
+	info := &RepoInfo{
+		Forge:         f.Name(),
+		Owner:         owner,
+		Repo:          repo,
+		URL:           data.HTMLURL,
+		Archived:      data.Archived,
+		DefaultBranch: data.DefaultBranch,
+		LastActivity:  data.UpdatedAt,
+	}
+	if data.FullName != "" && !strings.EqualFold(data.FullName, project) {
+		info.MovedTo = data.FullName
+	}
+
+	return info, nil
+}
@@ -0,0 +1,67 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultGitHubAPI is the base URL of the public GitHub REST API
+const DefaultGitHubAPI = "https://api.github.com"
+
+// gitHubForge checks repositories through the GitHub REST API
+type gitHubForge struct {
+	host    string
+	baseURL string
+	token   string
+}
+
+// githubRepo is the subset of the GitHub "get a repository" response we use
+type githubRepo struct {
+	FullName      string    `json:"full_name"`
+	HTMLURL       string    `json:"html_url"`
+	Archived      bool      `json:"archived"`
+	Disabled      bool      `json:"disabled"`
+	DefaultBranch string    `json:"default_branch"`
+	PushedAt      time.Time `json:"pushed_at"`
+}
+
+func (f *gitHubForge) Name() string { return "GitHub" }
+
+func (f *gitHubForge) Host() string { return f.host }
+
+func (f *gitHubForge) APIURL() string { return f.baseURL }
+
+func (f *gitHubForge) Check(ctx context.Context, get GetFunc, project string) (*RepoInfo, error) {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if f.token != "" {
+		header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	var data githubRepo
+	found, err := getJSON(ctx, get, fmt.Sprintf("%s/repos/%s", f.baseURL, project), header, &data)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	owner, repo := splitProject(project)
+	info := &RepoInfo{
+		Forge:         f.Name(),
+		Owner:         owner,
+		Repo:          repo,
+		URL:           data.HTMLURL,
+		Archived:      data.Archived,
+		Disabled:      data.Disabled,
+		DefaultBranch: data.DefaultBranch,
+		LastActivity:  data.PushedAt,
+	}
+	if data.FullName != "" && !strings.EqualFold(data.FullName, project) {
+		info.MovedTo = data.FullName
+	}
+
+	return info, nil
+}
@@ -0,0 +1,108 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGitHubRepo(t *testing.T) {
+	var header http.Header
+	api := newTestForgeAPI(t, map[string]string{
+		"/repos/active/repo":   `{"full_name":"active/repo","html_url":"https://github.com/active/repo","archived":false,"default_branch":"main","pushed_at":"2024-05-01T12:00:00Z"}`,
+		"/repos/archived/repo": `{"full_name":"archived/repo","archived":true}`,
+		"/repos/disabled/repo": `{"full_name":"disabled/repo","disabled":true}`,
+		"/repos/old/name":      "redirect:/repos/new/name",
+		"/repos/new/name":      `{"full_name":"new/name"}`,
+	}, &header)
+
+	forge, err := NewForge("github", "github.com", api.URL+"/", "secret-token")
+	assert.NoError(t, err)
+	get := clientGet(http.DefaultClient)
+
+	info, err := forge.Check(context.Background(), get, "active/repo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret-token", header.Get("Authorization"))
+	assert.Equal(t, "GitHub", info.Forge)
+	assert.Equal(t, "active", info.Owner)
+	assert.Equal(t, "repo", info.Repo)
+	assert.Equal(t, "https://github.com/active/repo", info.URL)
+	assert.Equal(t, "main", info.DefaultBranch)
+	assert.False(t, info.Archived)
+	assert.Equal(t, time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC), info.LastActivity)
+
+	info, err = forge.Check(context.Background(), get, "archived/repo")
+	assert.NoError(t, err)
+	assert.True(t, info.Archived)
+
+	info, err = forge.Check(context.Background(), get, "disabled/repo")
+	assert.NoError(t, err)
+	assert.True(t, info.Disabled)
+
+	info, err = forge.Check(context.Background(), get, "old/name")
+	assert.NoError(t, err)
+	assert.Equal(t, "new/name", info.MovedTo)
+
+	info, err = forge.Check(context.Background(), get, "missing/repo")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestCheckGitHubRepoError(t *testing.T) {
+	server := newFailingProxy(t, http.StatusForbidden)
+
+	forge, err := NewForge("github", "github.com", server.URL, "")
+	assert.NoError(t, err)
+
+	_, err = forge.Check(context.Background(), clientGet(http.DefaultClient), "rate/limited")
+	assert.ErrorContains(t, err, "status 403")
+}
+
+func TestPingPackageWithGitHub(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/archived/repo/v2/@v/list":        "v2.1.0\n",
+		"github.com/archived/repo/v2/@v/v2.1.0.info": `{"Version":"v2.1.0","Time":"` + recent + `"}`,
+		"github.com/old/name/@v/list":                "v1.0.0\n",
+		"github.com/old/name/@v/v1.0.0.info":         `{"Version":"v1.0.0","Time":"` + recent + `"}`,
+	})
+	api := newTestForgeAPI(t, map[string]string{
+		"/repos/archived/repo": `{"full_name":"archived/repo","html_url":"https://github.com/archived/repo","archived":true}`,
+		"/repos/old/name":      "redirect:/repos/new/name",
+		"/repos/new/name":      `{"full_name":"new/name","html_url":"https://github.com/new/name"}`,
+	}, nil)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	forge, err := NewForge("github", "github.com", api.URL, "")
+	assert.NoError(t, err)
+	client.AddForge(forge)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/archived/repo/v2"},
+		{Path: "github.com/old/name"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	archived := byPath["github.com/archived/repo/v2"]
+	assert.Equal(t, StatusArchived, archived.Status)
+	assert.True(t, archived.RepoArchived)
+	assert.Equal(t, "GitHub", archived.Forge)
+	assert.Equal(t, "archived", archived.Owner)
+	assert.Equal(t, "repo", archived.Repo)
+	assert.Equal(t, "https://github.com/archived/repo", archived.RepoURL)
+	assert.Equal(t, "Repository archived on GitHub", archived.Reason)
+
+	moved := byPath["github.com/old/name"]
+	assert.Equal(t, StatusActive, moved.Status)
+	assert.Equal(t, "new/name", moved.MovedTo)
+}
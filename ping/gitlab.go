@@ -0,0 +1,62 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// gitLabForge checks projects through the GitLab REST API (v4)
+type gitLabForge struct {
+	host    string
+	baseURL string
+	token   string
+}
+
+// gitlabProject is the subset of the GitLab "get a single project" response we use
+type gitlabProject struct {
+	PathWithNamespace string    `json:"path_with_namespace"`
+	WebURL            string    `json:"web_url"`
+	Archived          bool      `json:"archived"`
+	DefaultBranch     string    `json:"default_branch"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+}
+
+func (f *gitLabForge) Name() string { return "GitLab" }
+
+func (f *gitLabForge) Host() string { return f.host }
+
+func (f *gitLabForge) APIURL() string { return f.baseURL }
+
+func (f *gitLabForge) Check(ctx context.Context, get GetFunc, project string) (*RepoInfo, error) {
+	header := http.Header{}
+	if f.token != "" {
+		header.Set("PRIVATE-TOKEN", f.token)
+	}
+
+	// Projects are addressed by their URL-encoded full path, e.g. group%2Fsubgroup%2Fproject
+	var data gitlabProject
+	found, err := getJSON(ctx, get, fmt.Sprintf("%s/projects/%s", f.baseURL, url.PathEscape(project)), header, &data)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	owner, repo := splitProject(project)
+	info := &RepoInfo{
+		Forge:         f.Name(),
+		Owner:         owner,
+		Repo:          repo,
+		URL:           data.WebURL,
+		Archived:      data.Archived,
+		DefaultBranch: data.DefaultBranch,
+		LastActivity:  data.LastActivityAt,
+	}
+	if data.PathWithNamespace != "" && !strings.EqualFold(data.PathWithNamespace, project) {
+		info.MovedTo = data.PathWithNamespace
+	}
+
+	return info, nil
+}
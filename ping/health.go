@@ -0,0 +1,239 @@
+package ping
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// Signals the health score is computed from
+const (
+	HealthRecency         = "recency"         // How recently the module was published, relative to the unmaintained duration
+	HealthCadence         = "cadence"         // How many releases the module has had, or how its silence compares to its history
+	HealthMaintenance     = "maintenance"     // Archived, disabled, deprecated, not found or retracted
+	HealthDrift           = "drift"           // How far the required version is behind the latest release
+	HealthVulnerabilities = "vulnerabilities" // Known vulnerabilities affecting the required version
+)
+
+// HealthSignals lists every signal in the order they are reported
+var HealthSignals = []string{HealthRecency, HealthCadence, HealthMaintenance, HealthDrift, HealthVulnerabilities}
+
+// HealthWeights sets how much each signal contributes to the health score, keyed by signal name.
+// Weights are relative to each other, and signals that couldn't be observed are left out.
+type HealthWeights map[string]float64
+
+// DefaultHealthWeights returns the weights used unless SetHealthWeights is called
+func DefaultHealthWeights() HealthWeights {
+	return HealthWeights{
+		HealthRecency:         30,
+		HealthCadence:         20,
+		HealthMaintenance:     25,
+		HealthDrift:           15,
+		HealthVulnerabilities: 10,
+	}
+}
+
+// ParseHealthWeights overrides the weights in base with a comma-separated list of
+// signal=weight pairs, e.g. "recency=10,cadence=40"
+func ParseHealthWeights(spec string, base HealthWeights) (HealthWeights, error) {
+	weights := base.clone()
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid weight %q, expected signal=weight", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q: %v", pair, err)
+		}
+		weights[strings.TrimSpace(name)] = weight
+	}
+	return weights, weights.validate()
+}
+
+// LoadHealthWeights overrides the weights in base with the JSON object in the file at path,
+// e.g. {"recency": 10, "cadence": 40}
+func LoadHealthWeights(path string, base HealthWeights) (HealthWeights, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var overrides map[string]float64
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("invalid weights file %s: %v", path, err)
+	}
+
+	weights := base.clone()
+	for name, weight := range overrides {
+		weights[name] = weight
+	}
+	if err := weights.validate(); err != nil {
+		return nil, fmt.Errorf("invalid weights file %s: %v", path, err)
+	}
+	return weights, nil
+}
+
+// clone returns a copy of w that can be modified freely
+func (w HealthWeights) clone() HealthWeights {
+	weights := make(HealthWeights, len(w))
+	for name, weight := range w {
+		weights[name] = weight
+	}
+	return weights
+}
+
+// validate checks that every weight belongs to a known signal and that they can be combined
+func (w HealthWeights) validate() error {
+	total := 0.0
+	for name, weight := range w {
+		known := false
+		for _, signal := range HealthSignals {
+			known = known || signal == name
+		}
+		if !known {
+			return fmt.Errorf("unknown signal %q, expected one of %s", name, strings.Join(HealthSignals, ", "))
+		}
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("weight of %s must be a non-negative number", name)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("at least one weight must be positive")
+	}
+	return nil
+}
+
+// SetHealthWeights sets how much each signal contributes to the health score
+func (c *Client) SetHealthWeights(weights HealthWeights) {
+	c.healthWeights = weights.clone()
+}
+
+// Health is a 0-100 maintenance score along with the signals it was computed from
+type Health struct {
+	Score   int            `json:"score"`
+	Signals []HealthSignal `json:"signals"` // Only the signals that could be observed
+}
+
+// HealthSignal is the contribution of a single signal to the health score
+type HealthSignal struct {
+	Name   string  `json:"name"`   // One of the Health signal constants
+	Score  int     `json:"score"`  // 0-100
+	Weight float64 `json:"weight"` // Weight the score was given
+	Detail string  `json:"detail"` // What the score is based on
+}
+
+// Signal returns the named signal, or nil if it wasn't observed
+func (h *Health) Signal(name string) *HealthSignal {
+	for i := range h.Signals {
+		if h.Signals[i].Name == name {
+			return &h.Signals[i]
+		}
+	}
+	return nil
+}
+
+// healthInputs are the observations behind the health score that RepoStatus doesn't hold
+type healthInputs struct {
+	releasesKnown bool // The proxy listed the tagged versions
+	releases      int  // Tagged releases, pre-releases excluded
+	vulnsKnown    bool // The vulnerability database was consulted
+}
+
+// fullCadence is the number of releases that earns a full cadence score
+const fullCadence = 10
+
+// scoreHealth combines the observed signals into a health score, or returns nil if none were observed
+func scoreHealth(status RepoStatus, in healthInputs, weights HealthWeights, unmaintained time.Duration) *Health {
+	health := &Health{}
+	add := func(name string, score float64, detail string) {
+		health.Signals = append(health.Signals, HealthSignal{
+			Name:   name,
+			Score:  int(math.Round(100 * math.Max(0, math.Min(1, score)))),
+			Weight: weights[name],
+			Detail: detail,
+		})
+	}
+
+	// Halfway at the unmaintained duration, zero at twice that
+	if !status.LastPublished.IsZero() && unmaintained > 0 {
+		detail := "published " + status.LastPublished.Format("Jan 2, 2006")
+		if status.PublishedEstimated {
+			detail = "last commit " + status.LastPublished.Format("Jan 2, 2006") + " (pseudo-version)"
+		}
+		add(HealthRecency, 1-float64(time.Since(status.LastPublished))/float64(2*unmaintained), detail)
+	}
+
+	if cadence := status.Cadence; cadence != nil && cadence.Judged() {
+		if cadence.Anomalous {
+			add(HealthCadence, cadence.LongestGapDays/cadence.SilenceDays,
+				fmt.Sprintf("quiet for %.0f days, longest gap before %.0f days", cadence.SilenceDays, cadence.LongestGapDays))
+		} else {
+			add(HealthCadence, 1, fmt.Sprintf("%d releases, %.1f per year, within its usual rhythm", cadence.Releases, cadence.ReleasesPerYear))
+		}
+	} else if in.releasesKnown {
+		add(HealthCadence, float64(in.releases)/fullCadence, fmt.Sprintf("%d releases", in.releases))
+	}
+
+	switch {
+	case status.RepoArchived:
+		add(HealthMaintenance, 0, "repository archived")
+	case status.RepoDisabled:
+		add(HealthMaintenance, 0, "repository disabled")
+	case status.Deprecated != "":
+		add(HealthMaintenance, 0, "deprecated")
+	case status.Status == StatusNotFound:
+		add(HealthMaintenance, 0, "not found")
+	case status.Retracted:
+		add(HealthMaintenance, 0.5, "required version retracted")
+	case status.Status != StatusUnknown:
+		add(HealthMaintenance, 1, "no archived, deprecated or retracted flags")
+	}
+
+	switch status.Drift {
+	case DriftNone:
+		add(HealthDrift, 1, "up to date")
+	case DriftPatch:
+		add(HealthDrift, 0.9, "patch update available")
+	case DriftMinor:
+		add(HealthDrift, 0.7, "minor update available")
+	case DriftMajor:
+		add(HealthDrift, 0.4, "major update available")
+	}
+
+	if in.vulnsKnown {
+		add(HealthVulnerabilities, 1/float64(1+len(status.Vulnerabilities)), fmt.Sprintf("%d known vulnerabilities", len(status.Vulnerabilities)))
+	}
+
+	var total, weighted float64
+	for _, signal := range health.Signals {
+		total += signal.Weight
+		weighted += signal.Weight * float64(signal.Score)
+	}
+	if total == 0 {
+		return nil
+	}
+	health.Score = int(math.Round(weighted / total))
+	return health
+}
+
+// countReleases returns the number of tagged releases in versions, pre-releases excluded
+func countReleases(versions []string) int {
+	releases := 0
+	for _, v := range versions {
+		if semver.IsValid(v) && semver.Prerelease(v) == "" {
+			releases++
+		}
+	}
+	return releases
+}
@@ -0,0 +1,111 @@
+package ping
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHealthWeights(t *testing.T) {
+	weights, err := ParseHealthWeights("recency=10, cadence=40", DefaultHealthWeights())
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, weights[HealthRecency])
+	assert.Equal(t, 40.0, weights[HealthCadence])
+	assert.Equal(t, 25.0, weights[HealthMaintenance])
+
+	for _, spec := range []string{"recency", "recency=abc", "popularity=5", "drift=-1"} {
+		_, err := ParseHealthWeights(spec, DefaultHealthWeights())
+		assert.Error(t, err, spec)
+	}
+
+	_, err = ParseHealthWeights("recency=0,cadence=0,maintenance=0,drift=0,vulnerabilities=0", DefaultHealthWeights())
+	assert.Error(t, err)
+}
+
+func TestLoadHealthWeights(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "weights.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"drift": 0, "vulnerabilities": 50}`), 0o644))
+
+	weights, err := LoadHealthWeights(path, DefaultHealthWeights())
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, weights[HealthDrift])
+	assert.Equal(t, 50.0, weights[HealthVulnerabilities])
+	assert.Equal(t, 30.0, weights[HealthRecency])
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"stars": 1}`), 0o644))
+	_, err = LoadHealthWeights(path, DefaultHealthWeights())
+	assert.ErrorContains(t, err, "unknown signal")
+
+	_, err = LoadHealthWeights(filepath.Join(dir, "missing.json"), DefaultHealthWeights())
+	assert.Error(t, err)
+}
+
+func TestScoreHealth(t *testing.T) {
+	weights := DefaultHealthWeights()
+	unmaintained := 2 * 365 * 24 * time.Hour
+
+	// A finished library: nothing published for a while, but plenty of history and no flags
+	mature := RepoStatus{
+		Status:        StatusStale,
+		LastPublished: time.Now().Add(-3 * unmaintained / 2),
+		Drift:         DriftNone,
+	}
+	health := scoreHealth(mature, healthInputs{releasesKnown: true, releases: 25}, weights, unmaintained)
+	assert.Equal(t, 25, health.Signal(HealthRecency).Score)
+	assert.Equal(t, 100, health.Signal(HealthCadence).Score)
+	assert.Equal(t, 100, health.Signal(HealthMaintenance).Score)
+	assert.Equal(t, 100, health.Signal(HealthDrift).Score)
+	assert.Nil(t, health.Signal(HealthVulnerabilities))
+	// (30*25 + 20*100 + 25*100 + 15*100) / 90
+	assert.Equal(t, 75, health.Score)
+
+	archived := RepoStatus{
+		Status:          StatusArchived,
+		RepoArchived:    true,
+		LastPublished:   time.Now(),
+		Drift:           DriftMajor,
+		Vulnerabilities: []string{"GO-2024-0001"},
+	}
+	health = scoreHealth(archived, healthInputs{releasesKnown: true, releases: 2, vulnsKnown: true}, weights, unmaintained)
+	assert.Equal(t, "repository archived", health.Signal(HealthMaintenance).Detail)
+	assert.Equal(t, 0, health.Signal(HealthMaintenance).Score)
+	assert.Equal(t, 40, health.Signal(HealthDrift).Score)
+	assert.Equal(t, 50, health.Signal(HealthVulnerabilities).Score)
+	assert.Equal(t, "1 known vulnerabilities", health.Signal(HealthVulnerabilities).Detail)
+	// (30*100 + 20*20 + 25*0 + 15*40 + 10*50) / 100
+	assert.Equal(t, 45, health.Score)
+
+	// Nothing observed, nothing to score
+	assert.Nil(t, scoreHealth(RepoStatus{Status: StatusUnknown}, healthInputs{}, weights, unmaintained))
+
+	// Signals weighted zero are reported but don't count
+	health = scoreHealth(mature, healthInputs{}, HealthWeights{HealthRecency: 0, HealthMaintenance: 1}, unmaintained)
+	assert.Equal(t, 100, health.Score)
+	assert.Equal(t, 0.0, health.Signal(HealthRecency).Weight)
+}
+
+func TestPingPackageHealth(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/@v/list":        "v1.0.0\nv1.1.0\nv1.2.0-rc.1\nv1.2.0\n",
+		"github.com/example/lib/@v/v1.2.0.info": `{"Version":"v1.2.0","Time":"` + recent + `"}`,
+		"github.com/example/lib/@v/v1.2.0.mod":  "module github.com/example/lib\n",
+	})
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetHealthWeights(HealthWeights{HealthCadence: 1})
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib", Version: "v1.2.0"}})
+	assert.Len(t, results, 1)
+	assert.NotNil(t, results[0].Health)
+	assert.Equal(t, "3 releases", results[0].Health.Signal(HealthCadence).Detail)
+	assert.Equal(t, 30, results[0].Health.Score)
+}
@@ -0,0 +1,54 @@
+package ping
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// errOffline is returned for any HTTP request attempted in offline mode
+var errOffline = errors.New("network access disabled in offline mode")
+
+// DefaultModCache returns the module cache directory the go command uses:
+// $GOMODCACHE, or else pkg/mod under the first entry of $GOPATH (default ~/go)
+func DefaultModCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(os.Getenv("GOPATH"))
+	if len(gopath) > 0 && gopath[0] != "" {
+		return filepath.Join(gopath[0], "pkg", "mod")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "go", "pkg", "mod")
+}
+
+// SetOffline makes the client read module metadata only from the download cache
+// under modCacheDir (laid out like a file:// GOPROXY) and never touch the network.
+// Repository checks and vanity path discovery are skipped, and modules without
+// cached data are reported as not cached.
+func (c *Client) SetOffline(modCacheDir string) error {
+	dir, err := filepath.Abs(filepath.Join(modCacheDir, "cache", "download"))
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+
+	c.offline = true
+	c.proxies = []proxySpec{{url: "file://" + filepath.ToSlash(dir)}}
+	c.httpClient.Transport = offlineTransport{}
+	return nil
+}
+
+// offlineTransport fails every request, so nothing slips out in offline mode
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errOffline
+}
@@ -0,0 +1,125 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestModCache creates a module cache whose download directory holds the given
+// files, keyed by their path below cache/download (e.g. "github.com/a/b/@v/list")
+func newTestModCache(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, "cache", "download", filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestDefaultModCache(t *testing.T) {
+	t.Setenv("GOMODCACHE", "/tmp/modcache")
+	assert.Equal(t, "/tmp/modcache", DefaultModCache())
+
+	t.Setenv("GOMODCACHE", "")
+	t.Setenv("GOPATH", "/tmp/gopath"+string(filepath.ListSeparator)+"/tmp/other")
+	assert.Equal(t, filepath.Join("/tmp/gopath", "pkg", "mod"), DefaultModCache())
+}
+
+func TestSetOffline(t *testing.T) {
+	client := NewClient()
+	assert.Error(t, client.SetOffline(t.TempDir()))
+	assert.False(t, client.offline)
+
+	assert.NoError(t, client.SetOffline(newTestModCache(t, map[string]string{"github.com/a/b/@v/list": ""})))
+	assert.True(t, client.offline)
+
+	_, err := client.httpClient.Get("https://proxy.golang.org/github.com/a/b/@v/list")
+	assert.ErrorIs(t, err, errOffline)
+}
+
+func TestPingPackageOffline(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	modCache := newTestModCache(t, map[string]string{
+		"github.com/!burnt!sushi/toml/@v/list":        "v1.2.0\nv1.3.2\n",
+		"github.com/!burnt!sushi/toml/@v/v1.3.2.info": `{"Version":"v1.3.2","Time":"` + recent + `"}`,
+		"github.com/!burnt!sushi/toml/@v/v1.2.0.info": `{"Version":"v1.2.0","Time":"2022-08-01T00:00:00Z"}`,
+		"corp.example.com/internal/@v/list":           "v0.1.0\n",
+		"corp.example.com/internal/@v/v0.1.0.info":    `{"Version":"v0.1.0","Time":"2019-03-01T00:00:00Z"}`,
+		"github.com/!burnt!sushi/toml/@v/v1.3.2.mod":  "module github.com/BurntSushi/toml\n",
+		// Filled by go mod download, which writes no @v/list
+		"github.com/pkg/errors/@v/v0.9.1.info": `{"Version":"v0.9.1","Time":"` + recent + `"}`,
+		"github.com/pkg/errors/@v/v0.9.1.mod":  "module github.com/pkg/errors\n",
+		"github.com/pkg/errors/@v/v0.9.1.zip":  "",
+		"github.com/pkg/errors/@v/v0.8.0.info": `{"Version":"v0.8.0","Time":"2019-01-01T00:00:00Z"}`,
+	})
+
+	var apiRequests int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiRequests, 1)
+	}))
+	t.Cleanup(api.Close)
+
+	client := NewClient()
+	client.SetPrivatePatterns(PrivatePatterns{Private: "corp.example.com", NoProxy: "corp.example.com"})
+	client.SetDiscoverRepos(true)
+	forge, err := NewForge("github", "github.com", api.URL, "")
+	assert.NoError(t, err)
+	client.AddForge(forge)
+	assert.NoError(t, client.SetOffline(modCache))
+
+	progress := make(map[string]string)
+	var mu sync.Mutex
+	client.SetProgressCallback(func(dependency string, status string) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress[dependency] = status
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/BurntSushi/toml", Version: "v1.2.0"},
+		{Path: "corp.example.com/internal", Version: "v0.1.0"},
+		{Path: "github.com/missing/module", Version: "v1.0.0"},
+		{Path: "go.uber.org/zap", Version: "v1.27.0"},
+		{Path: "github.com/pkg/errors", Version: "v0.8.0"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	toml := byPath["github.com/BurntSushi/toml"]
+	assert.Equal(t, "v1.3.2", toml.LatestVersion)
+	assert.Equal(t, DriftMinor, toml.Drift)
+	assert.Equal(t, StatusActive, toml.Status)
+	assert.Empty(t, toml.Forge)
+	assert.Empty(t, toml.Error)
+
+	// Private modules never leave the machine offline, so they are checked too
+	internal := byPath["corp.example.com/internal"]
+	assert.False(t, internal.Private)
+	assert.Equal(t, StatusStale, internal.Status)
+
+	errorsPkg := byPath["github.com/pkg/errors"]
+	assert.False(t, errorsPkg.NotCached)
+	assert.Equal(t, "v0.9.1", errorsPkg.LatestVersion)
+	assert.Equal(t, StatusActive, errorsPkg.Status)
+
+	assert.True(t, byPath["github.com/missing/module"].NotCached)
+	assert.Equal(t, "Not cached (Not checked)", progress["github.com/missing/module"])
+	assert.True(t, byPath["go.uber.org/zap"].NotCached)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&apiRequests))
+}
@@ -1,55 +1,188 @@
 package ping
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
-	"github.com/Bhupesh-V/godeping/parsers/pkginfo"
+	"golang.org/x/mod/module"
 )
 
 // RepoStatus contains information about a repository's status
 type RepoStatus struct {
-	ModulePath    string    `json:"module_path"`
-	Owner         string    `json:"-"`
-	Repo          string    `json:"-"`
-	IsArchived    bool      `json:"-"`
-	StatusCode    int       `json:"-"`
-	Error         string    `json:"-"`
-	LastPublished time.Time `json:"last_published"`
-	Reason        string    `json:"-"`
+	ModulePath         string      `json:"module_path"`
+	Owner              string      `json:"owner,omitempty"`
+	Repo               string      `json:"repo,omitempty"`
+	Status             Status      `json:"status"`                // Verdict, see Statuses for the precedence between them
+	StatusCode         int         `json:"status_code,omitempty"` // HTTP status of the lookup that decided the status
+	Error              string      `json:"error,omitempty"`
+	LastPublished      time.Time   `json:"last_published"`
+	PublishedEstimated bool        `json:"published_estimated,omitempty"` // LastPublished is the commit time of the required pseudo-version
+	Version            string      `json:"version,omitempty"`             // Version required by our go.mod
+	VersionPublished   time.Time   `json:"version_published"`             // When the required version was published
+	LatestVersion      string      `json:"latest_version,omitempty"`
+	LatestMajorPath    string      `json:"latest_major_path,omitempty"` // Newest module path with a higher major version, e.g. example.com/mod/v3
+	LatestMajorVersion string      `json:"latest_major_version,omitempty"`
+	Drift              string      `json:"drift,omitempty"`      // One of DriftNone, DriftPatch, DriftMinor or DriftMajor
+	Deprecated         string      `json:"deprecated,omitempty"` // Deprecation message from the latest go.mod
+	Retracted          bool        `json:"retracted,omitempty"`  // The required version was retracted by its author
+	RetractRationale   string      `json:"retract_rationale,omitempty"`
+	Private            bool        `json:"private,omitempty"`    // Matched GOPRIVATE/GONOPROXY/GONOSUMDB and was not checked
+	NotCached          bool        `json:"not_cached,omitempty"` // Offline mode found no data in the module cache
+	Cached             bool        `json:"cached,omitempty"`     // Some lookups were answered from the on-disk cache
+	Retries            int         `json:"retries"`              // Requests retried after transient failures
+	Incomplete         bool        `json:"incomplete,omitempty"` // The check was interrupted before it finished
+	Forge              string      `json:"forge,omitempty"`
+	RepoURL            string      `json:"repo_url,omitempty"`
+	RepoArchived       bool        `json:"repo_archived,omitempty"` // Archived or otherwise read-only on its forge
+	RepoDisabled       bool        `json:"repo_disabled,omitempty"`
+	MovedTo            string      `json:"moved_to,omitempty"` // New project path if the repository was renamed or transferred
+	DefaultBranch      string      `json:"default_branch,omitempty"`
+	LastActivity       time.Time   `json:"last_activity"`
+	RepoError          string      `json:"repo_error,omitempty"`
+	Cadence            *Cadence    `json:"cadence,omitempty"`         // Release history, only with SetCadence
+	Vulnerabilities    []string    `json:"vulnerabilities,omitempty"` // IDs of known vulnerabilities affecting the required version
+	Health             *Health     `json:"health,omitempty"`          // Nil if the dependency wasn't checked
+	Accepted           *Acceptance `json:"accepted,omitempty"`        // Set by the caller when the findings were acknowledged
+	Baselined          bool        `json:"baselined,omitempty"`       // Set by the caller when the findings are already in the baseline
+	Reason             string      `json:"reason,omitempty"`
+	Evidence           []Evidence  `json:"evidence"` // Observations the status is based on, in the order they were made
+}
+
+// lookupResult is what a lookup source reports about the latest release of a module
+type lookupResult struct {
+	StatusCode int
+	Version    string
+	Published  time.Time
+	Versions   []string // Tagged versions listed by the proxy, nil if the source doesn't list them
+	Source     string   // Where the information came from, e.g. "proxy.golang.org"
+	proxyURL   string   // Proxy that served the information, empty if it did not come from one
 }
 
 // Client is an HTTP client for checking module status
 type Client struct {
 	httpClient           *http.Client
-	unmaintainedDuration time.Duration // Duration after which a module is considered unmaintained
+	unmaintainedDuration time.Duration            // Duration after which a module is considered unmaintained
+	unmaintainedPatterns map[string]time.Duration // Overrides of unmaintainedDuration keyed by module path pattern
 	progress             func(dependency string, status string)
+	proxies              []proxySpec      // Parsed GOPROXY list used for version lookups
+	private              PrivatePatterns  // Modules that must not be sent to public services
+	forges               map[string]Forge // Repository checkers keyed by host
+	discoverRepos        bool             // Resolve vanity import paths through ?go-get=1 discovery
+	repoRoots            repoRootCache
+	offline              bool   // Only read from the module cache, see SetOffline
+	cache                *Cache // On-disk response cache, nil to always fetch
+	concurrency          int    // Number of dependencies checked at the same time
+	healthWeights        HealthWeights
+	cadence              bool    // Fetch the release history of each module, see SetCadence
+	vulns                *vulnDB // Vulnerability database, nil to skip vulnerability lookups
 }
 
+// DefaultRequestTimeout bounds a single HTTP request, including its retries
+const DefaultRequestTimeout = 2 * time.Minute
+
 // NewClient creates a new client
 func NewClient() *Client {
+	proxies, _ := parseGoProxy(DefaultGoProxy)
 	return &Client{
-		httpClient:           &http.Client{Timeout: 10 * time.Minute},
+		httpClient: &http.Client{
+			Timeout:   DefaultRequestTimeout,
+			Transport: &retryTransport{policy: DefaultRetryPolicy, limiter: newHostLimiter(DefaultRateLimit)},
+		},
 		unmaintainedDuration: 2 * 365 * 24 * time.Hour, // Default: 2 years
+		proxies:              proxies,
+		concurrency:          DefaultConcurrency,
+		healthWeights:        DefaultHealthWeights(),
 	}
 }
 
+// SetGoProxy sets the module proxies to query, using the same syntax as the GOPROXY
+// environment variable. An empty value selects the go command's default.
+func (c *Client) SetGoProxy(goproxy string) error {
+	proxies, err := parseGoProxy(goproxy)
+	if err != nil {
+		return err
+	}
+	c.proxies = proxies
+	return nil
+}
+
+// SetCache stores lookup responses in cache and answers from it while they are fresh.
+// A nil cache turns caching off.
+func (c *Client) SetCache(cache *Cache) {
+	c.cache = cache
+}
+
+// SetRequestTimeout sets how long a single HTTP request, including its retries, may take.
+// Zero means no limit other than the context passed to PingPackage.
+func (c *Client) SetRequestTimeout(d time.Duration) {
+	c.httpClient.Timeout = d
+}
+
 // SetUnmaintainedDuration sets the duration threshold for considering a dependency unmaintained
 func (c *Client) SetUnmaintainedDuration(d time.Duration) {
 	c.unmaintainedDuration = d
 }
 
+// SetUnmaintainedDurationFor overrides the unmaintained duration for modules matching pattern,
+// a glob matched against module path prefixes like the ones in GOPRIVATE. When several
+// patterns match a module, the longest one wins.
+func (c *Client) SetUnmaintainedDurationFor(pattern string, d time.Duration) {
+	if c.unmaintainedPatterns == nil {
+		c.unmaintainedPatterns = make(map[string]time.Duration)
+	}
+	c.unmaintainedPatterns[pattern] = d
+}
+
+// unmaintainedDurationOf returns the unmaintained duration that applies to modPath
+func (c *Client) unmaintainedDurationOf(modPath string) time.Duration {
+	d, best := c.unmaintainedDuration, ""
+	for pattern, override := range c.unmaintainedPatterns {
+		if !module.MatchPrefixPatterns(pattern, modPath) {
+			continue
+		}
+		// Ties are broken alphabetically so that the result doesn't depend on map order
+		if best == "" || len(pattern) > len(best) || (len(pattern) == len(best) && pattern < best) {
+			d, best = override, pattern
+		}
+	}
+	return d
+}
+
 // SetProgressCallback sets the callback function to report progress
 func (c *Client) SetProgressCallback(callback func(dependency string, status string)) {
 	c.progress = callback
 }
 
-// PingPackage checks which dependencies appear to be archived by checking their status on pkg.go.dev
-func (c *Client) PingPackage(deps []parser.Dependency) []RepoStatus {
+// SetPrivatePatterns sets the patterns of private modules, which are never sent to public services
+func (c *Client) SetPrivatePatterns(patterns PrivatePatterns) {
+	c.private = patterns
+}
+
+// AddForge enables repository checks for modules hosted on the forge's host,
+// replacing any forge previously added for the same host
+func (c *Client) AddForge(forge Forge) {
+	if c.forges == nil {
+		c.forges = make(map[string]Forge)
+	}
+	c.forges[forge.Host()] = forge
+}
+
+// SetDiscoverRepos enables resolving vanity import paths (go.uber.org/zap, k8s.io/client-go, ...)
+// to their repositories through the go-import meta tags served at https://<module>?go-get=1
+func (c *Client) SetDiscoverRepos(enabled bool) {
+	c.discoverRepos = enabled
+}
+
+// PingPackage checks which dependencies appear to be archived by looking up their latest release
+// through the configured module proxies. If ctx is cancelled or its deadline passes, in-flight
+// requests are aborted and the dependencies that could not be checked are marked Incomplete.
+func (c *Client) PingPackage(ctx context.Context, deps []parser.Dependency) []RepoStatus {
 	// Filter out indirect dependencies
 	var directDeps []parser.Dependency
 	for _, dep := range deps {
@@ -61,8 +194,8 @@ func (c *Client) PingPackage(deps []parser.Dependency) []RepoStatus {
 	resultChan := make(chan RepoStatus, len(directDeps))
 	var wg sync.WaitGroup
 
-	// Create a semaphore channel to limit concurrent requests to 10
-	semaphore := make(chan struct{}, 10)
+	// Create a semaphore channel to limit the number of concurrent checks
+	semaphore := make(chan struct{}, c.concurrency)
 
 	// Launch a goroutine for each dependency
 	for _, dep := range directDeps {
@@ -70,40 +203,18 @@ func (c *Client) PingPackage(deps []parser.Dependency) []RepoStatus {
 		go func(dep parser.Dependency) {
 			defer wg.Done()
 
-			// Acquire semaphore slot
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			// Initialize status
-			status := RepoStatus{
-				ModulePath: dep.Path,
-			}
-
-			// Check package status on pkg.go.dev
-			statusCode, _, publishDate, err := c.checkPackageStatus(dep.Path)
-			status.StatusCode = statusCode
-			status.LastPublished = publishDate
-
-			if err != nil {
-				status.Error = err.Error()
-				c.progress(dep.Path, "Error: "+err.Error())
-			} else {
-				// Primary check: Is the published date older than the configured duration?
-				if !publishDate.IsZero() && time.Since(publishDate) > c.unmaintainedDuration {
-					status.IsArchived = true
-					status.Reason = fmt.Sprintf("Not updated since %s", publishDate.Format("Jan 2, 2006"))
-					c.progress(dep.Path, "Archived (Last published: "+publishDate.Format("Jan 2, 2006")+")")
-				} else if statusCode == http.StatusNotFound {
-					// Secondary check: Is the package not found on pkg.go.dev?
-					status.IsArchived = true
-					status.Reason = "404 from pkg.go.dev"
-					c.progress(dep.Path, "Archived (Not found on pkg.go.dev)")
-				} else {
-					// Recent publish date and status code is OK
-					c.progress(dep.Path, "Active (Last published: "+publishDate.Format("Jan 2, 2006")+")")
-				}
+			// Acquire semaphore slot, unless the run was cancelled while waiting for one
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				resultChan <- c.incomplete(ctx, RepoStatus{ModulePath: dep.Path, Version: dep.Version})
+				return
 			}
 
+			var retries int32
+			status := c.checkDependency(withRetryCounter(ctx, &retries), dep)
+			status.Retries = int(atomic.LoadInt32(&retries))
 			resultChan <- status
 		}(dep)
 	}
@@ -123,32 +234,264 @@ func (c *Client) PingPackage(deps []parser.Dependency) []RepoStatus {
 	return results
 }
 
-// checkPackageStatus checks if a package exists on pkg.go.dev and extracts info
-func (c *Client) checkPackageStatus(pkgPath string) (statusCode int, repoURL string, publishDate time.Time, err error) {
-	url := fmt.Sprintf("https://pkg.go.dev/%s", pkgPath)
+// incomplete marks a dependency whose check was cut short by ctx
+func (c *Client) incomplete(ctx context.Context, status RepoStatus) RepoStatus {
+	status.Status = StatusUnknown
+	status.Incomplete = true
+	status.Error = ctx.Err().Error()
+	status.Reason = "Not checked: " + ctx.Err().Error()
+	c.progress(status.ModulePath, "Incomplete ("+ctx.Err().Error()+")")
+	return status
+}
+
+// checkDependency looks up a single dependency and decides whether it is still maintained
+func (c *Client) checkDependency(ctx context.Context, dep parser.Dependency) RepoStatus {
+	status := RepoStatus{
+		ModulePath: dep.Path,
+		Version:    dep.Version,
+	}
 
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return 0, "", time.Time{}, err
+	// Look up the latest release through the module proxies
+	result, err := c.checkProxyStatus(ctx, dep.Path)
+	if errors.Is(err, errPrivate) {
+		status.Status = StatusUnknown
+		status.Private = true
+		status.Reason = "Private module, not checked"
+		status.addEvidence("environment", SignalPrivate, "matches GOPRIVATE, GONOPROXY or GONOSUMDB", time.Time{}, "")
+		c.progress(dep.Path, "Private (Not checked)")
+		return status
+	}
+	if c.offline && err == nil && result.StatusCode == http.StatusNotFound {
+		status.Status = StatusUnknown
+		status.NotCached = true
+		status.Reason = "Not in the module cache, not checked"
+		status.addEvidence("module cache", SignalNotCached, "no version list or version info", time.Time{}, "")
+		c.progress(dep.Path, "Not cached (Not checked)")
+		return status
 	}
-	defer resp.Body.Close()
 
-	// Return the status code regardless
-	statusCode = resp.StatusCode
+	status.StatusCode = result.StatusCode
+	status.LastPublished = result.Published
+	status.LatestVersion = result.Version
 
-	// If it's not a successful response, return early
-	if resp.StatusCode != http.StatusOK {
-		return statusCode, "", time.Time{}, nil
+	switch {
+	case err != nil:
+		source := result.Source
+		if source == "" {
+			source = "module proxy"
+		}
+		status.addEvidence(source, SignalLookupError, err.Error(), time.Time{}, "")
+	case result.StatusCode == http.StatusNotFound:
+		status.addEvidence(result.Source, SignalNotFound, fmt.Sprintf("HTTP %d", result.StatusCode), time.Time{}, "")
+	case result.proxyURL != "":
+		infoURL := versionResourceURL(result.proxyURL, dep.Path, result.Version, ".info")
+		status.addEvidence(result.Source, SignalLatestRelease, result.Version, result.Published, infoURL)
 	}
 
-	// Try to extract repository URL and publish date from HTML
-	body, err := io.ReadAll(resp.Body)
+	// The latest go.mod tells whether the author deprecated the module or retracted the
+	// version we require. Failing to fetch it is not fatal, as the proxy already answered
+	// for the version itself.
+	if err == nil && result.proxyURL != "" && result.Version != "" {
+		if mod, modErr := c.goModFromProxy(ctx, result.proxyURL, dep.Path, result.Version); modErr == nil {
+			modURL := versionResourceURL(result.proxyURL, dep.Path, result.Version, ".mod")
+			status.Deprecated = mod.Deprecated
+			if mod.Deprecated != "" {
+				status.addEvidence(result.Source, SignalDeprecated, mod.Deprecated, result.Published, modURL)
+			}
+			for _, retract := range mod.Retracts {
+				if dep.Version != "" && retract.Covers(dep.Version) {
+					status.Retracted = true
+					status.RetractRationale = retract.Rationale
+					status.addEvidence(result.Source, SignalRetracted, fmt.Sprintf("[%s, %s] %s", retract.Low, retract.High, retract.Rationale), time.Time{}, modURL)
+					break
+				}
+			}
+		}
+	}
+
+	// Work out how far behind the latest release we are
+	if err == nil && result.proxyURL != "" && dep.Version != "" {
+		c.checkDrift(ctx, result.proxyURL, dep, &status)
+		switch {
+		case status.LatestMajorPath != "":
+			status.addEvidence(result.Source, SignalNewerVersion, status.LatestMajorPath+"@"+status.LatestMajorVersion, time.Time{}, "")
+		case status.Drift != DriftNone:
+			status.addEvidence(result.Source, SignalNewerVersion, status.LatestVersion+" ("+status.Drift+")", result.Published, "")
+		}
+	}
+
+	// Work out the release cadence from the publish time of every version
+	if c.cadence && err == nil && result.proxyURL != "" {
+		cadence, cadenceErr := c.releaseHistory(ctx, result.proxyURL, dep.Path, result.Versions)
+		if cadenceErr != nil {
+			status.addEvidence(result.Source, SignalLookupError, cadenceErr.Error(), time.Time{}, "")
+		} else if cadence != nil {
+			status.Cadence = cadence
+			status.addEvidence(result.Source, SignalCadence, cadence.String(), cadence.LastRelease, "")
+		}
+	}
+
+	// Look up known vulnerabilities of the required version. Only the database's index and
+	// the entries it lists are downloaded, so the module path itself is never sent.
+	inputs := healthInputs{releasesKnown: result.proxyURL != "", releases: countReleases(result.Versions)}
+	if c.vulns != nil && !c.offline && dep.Version != "" {
+		ids, vulnErr := c.vulnerabilities(ctx, dep.Path, dep.Version)
+		if vulnErr != nil {
+			status.addEvidence(proxyHost(c.vulns.url), SignalLookupError, vulnErr.Error(), time.Time{}, "")
+		} else {
+			inputs.vulnsKnown = true
+			status.Vulnerabilities = ids
+			for _, id := range ids {
+				status.addEvidence(proxyHost(c.vulns.url), SignalVulnerability, id, time.Time{}, "https://pkg.go.dev/vuln/"+id)
+			}
+		}
+	}
+
+	// A pseudo-version carries the time of its commit. It is only a lower bound on
+	// how recently the module changed, so it is used when nothing better is known.
+	if pv := dep.PseudoVersion(); pv != nil {
+		status.addEvidence("go.mod", SignalPseudoVersion, dep.Version, pv.Time, "")
+		if status.VersionPublished.IsZero() {
+			status.VersionPublished = pv.Time
+		}
+		if status.LastPublished.IsZero() {
+			status.LastPublished = pv.Time
+			status.PublishedEstimated = true
+		}
+	}
+
+	// Find the repository behind the module path, resolving vanity import paths
+	host, project, ok := repoRootFromModulePath(dep.Path)
+	root, rootErr := c.resolveRepoRoot(ctx, dep.Path)
+	if rootErr != nil {
+		status.RepoError = rootErr.Error()
+		status.addEvidence("go-import meta tag", SignalLookupError, rootErr.Error(), time.Time{}, "https://"+dep.Path+"?go-get=1")
+	} else if root != nil {
+		status.RepoURL = webURL(root)
+		host, project, ok = forgeProject(root.RepoURL)
+		if staticRepoRoot(dep.Path) != nil {
+			status.addEvidence("module path", SignalRepoRoot, root.RepoURL, time.Time{}, "")
+		} else {
+			status.addEvidence("go-import meta tag", SignalRepoRoot, root.RepoURL, time.Time{}, "https://"+dep.Path+"?go-get=1")
+		}
+	}
+
+	// Ask the hosting forge about the repository itself, independently of the release history
+	if ok && c.forges[host] != nil && !c.offline {
+		status.Owner, status.Repo = splitProject(project)
+		// Responses are cached per API endpoint and project, so modules sharing a repository
+		// share them too, and answers from another endpoint of the forge are never used
+		forge := c.forges[host]
+		get := func(ctx context.Context, target string, header http.Header) (int, []byte, error) {
+			return c.cachedGet(ctx, forge.APIURL(), host+"/"+project, "repo", target, header)
+		}
+		repo, repoErr := forge.Check(ctx, get, project)
+		if repoErr != nil {
+			status.RepoError = repoErr.Error()
+			status.addEvidence(forge.Name(), SignalLookupError, repoErr.Error(), time.Time{}, "")
+		} else if repo != nil {
+			status.Forge = repo.Forge
+			if repo.URL != "" {
+				status.RepoURL = repo.URL
+			}
+			status.RepoArchived = repo.Archived
+			status.RepoDisabled = repo.Disabled
+			status.MovedTo = repo.MovedTo
+			status.DefaultBranch = repo.DefaultBranch
+			status.LastActivity = repo.LastActivity
+
+			if repo.Archived {
+				status.addEvidence(repo.Forge, SignalRepoArchived, "archived", time.Time{}, status.RepoURL)
+			}
+			if repo.Disabled {
+				status.addEvidence(repo.Forge, SignalRepoDisabled, "disabled", time.Time{}, status.RepoURL)
+			}
+			if repo.MovedTo != "" {
+				status.addEvidence(repo.Forge, SignalRepoMoved, repo.MovedTo, time.Time{}, status.RepoURL)
+			}
+			if !repo.LastActivity.IsZero() {
+				status.addEvidence(repo.Forge, SignalRepoActivity, repo.DefaultBranch, repo.LastActivity, status.RepoURL)
+			}
+		}
+	}
+
+	// Extra notes for the progress line
+	notes := ""
+	if status.MovedTo != "" {
+		notes += ", moved to " + status.MovedTo
+	}
+	if status.Retracted {
+		notes += ", " + dep.Version + " retracted"
+	}
+	if status.Drift != "" && status.Drift != DriftNone {
+		notes += ", " + status.Drift + " update available"
+	}
+	if status.Cadence != nil && status.Cadence.Anomalous {
+		notes += ", unusually quiet"
+	}
+	if len(status.Vulnerabilities) > 0 {
+		notes += fmt.Sprintf(", %d known vulnerabilities", len(status.Vulnerabilities))
+	}
+	if c.cache != nil && c.cache.served(dep.Path) {
+		status.Cached = true
+		notes += ", cached"
+	}
+	published := "Last published: " + status.LastPublished.Format("Jan 2, 2006")
+	if status.PublishedEstimated {
+		published = "Last commit: " + status.LastPublished.Format("Jan 2, 2006") + " from pseudo-version"
+	}
 	if err != nil {
-		return statusCode, "", time.Time{}, err
+		status.Error = err.Error()
+	}
+	if ctx.Err() != nil {
+		// Whatever we found out so far is kept, but the verdict can't be trusted
+		return c.incomplete(ctx, status)
+	}
+
+	// A module's own release history is a better yardstick than a global duration, when there's enough of it
+	unmaintained := c.unmaintainedDurationOf(dep.Path)
+	stale := !status.LastPublished.IsZero() && time.Since(status.LastPublished) > unmaintained
+	staleReason := fmt.Sprintf("Not updated since %s", status.LastPublished.Format("Jan 2, 2006"))
+	if status.Cadence != nil && status.Cadence.Judged() {
+		stale = status.Cadence.Anomalous
+		staleReason = fmt.Sprintf("Quiet for %.0f days, longer than any gap between its %d releases (at most %.0f days)",
+			status.Cadence.SilenceDays, status.Cadence.Releases, status.Cadence.LongestGapDays)
+	}
+
+	// The cases follow the precedence of Statuses
+	switch {
+	case status.RepoArchived:
+		status.Status = StatusArchived
+		status.Reason = "Repository archived on " + status.Forge
+		c.progress(dep.Path, "Archived ("+status.Reason+notes+")")
+	case status.RepoDisabled:
+		status.Status = StatusArchived
+		status.Reason = "Repository disabled on " + status.Forge
+		c.progress(dep.Path, "Archived ("+status.Reason+notes+")")
+	case status.Deprecated != "":
+		status.Status = StatusDeprecated
+		status.Reason = "Deprecated: " + status.Deprecated
+		c.progress(dep.Path, "Deprecated ("+status.Deprecated+notes+")")
+	case err == nil && result.StatusCode == http.StatusNotFound:
+		// The module is unknown to the lookup source
+		status.Status = StatusNotFound
+		status.Reason = "404 from " + result.Source
+		c.progress(dep.Path, "Not found (Not found on "+result.Source+notes+")")
+	case stale:
+		status.Status = StatusStale
+		status.Reason = staleReason
+		c.progress(dep.Path, "Stale ("+published+notes+")")
+	case err != nil && !status.PublishedEstimated:
+		// A recent pseudo-version stands in for the failed lookups, anything else is unknown
+		status.Status = StatusUnknown
+		c.progress(dep.Path, "Error: "+err.Error())
+	default:
+		// Recent publish date and status code is OK
+		status.Status = StatusActive
+		c.progress(dep.Path, "Active ("+published+notes+")")
 	}
 
-	htmlContent := string(body)
-	publishDate = pkginfo.ExtractPublishDate(htmlContent)
+	status.Health = scoreHealth(status, inputs, c.healthWeights, unmaintained)
 
-	return statusCode, repoURL, publishDate, nil
+	return status
 }
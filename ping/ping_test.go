@@ -1,10 +1,8 @@
 package ping
 
 import (
-	"bytes"
-	"io"
+	"context"
 	"net/http"
-	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -18,70 +16,7 @@ func TestNewClient(t *testing.T) {
 	client := NewClient()
 	assert.NotNil(t, client)
 	assert.NotNil(t, client.httpClient)
-	assert.Equal(t, 10*time.Minute, client.httpClient.Timeout)
-}
-
-func TestCheckPackageStatus(t *testing.T) {
-	tests := []struct {
-		name           string
-		pkgPath        string
-		serverResponse string
-		statusCode     int
-		expectError    bool
-		publishDate    time.Time
-	}{
-		{
-			name:           "Successful response",
-			pkgPath:        "github.com/example/pkg",
-			serverResponse: `<span data-test-id="UnitHeader-commitTime">Jan 15, 2023</span>`,
-			statusCode:     http.StatusOK,
-			expectError:    false,
-			publishDate:    time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			name:           "Not found response",
-			pkgPath:        "github.com/notexist/pkg",
-			serverResponse: "Not Found",
-			statusCode:     http.StatusNotFound,
-			expectError:    false,
-			publishDate:    time.Time{},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Create test server
-			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(tt.statusCode)
-				w.Write([]byte(tt.serverResponse))
-			}))
-			defer server.Close()
-
-			// Create a client with custom transport that redirects to our test server
-			client := NewClient()
-			originalTransport := client.httpClient.Transport
-			client.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
-				req.URL.Scheme = "http"
-				req.URL.Host = server.Listener.Addr().String()
-				if originalTransport == nil {
-					return http.DefaultTransport.RoundTrip(req)
-				}
-				return originalTransport.RoundTrip(req)
-			})
-
-			// Call the function
-			statusCode, _, publishDate, err := client.checkPackageStatus(tt.pkgPath)
-
-			// Assertions
-			if tt.expectError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.statusCode, statusCode)
-				assert.Equal(t, tt.publishDate, publishDate)
-			}
-		})
-	}
+	assert.Equal(t, DefaultRequestTimeout, client.httpClient.Timeout)
 }
 
 type roundTripperFunc func(*http.Request) (*http.Response, error)
@@ -90,32 +25,18 @@ func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req)
 }
 
-// MockHTTPClient implements http.Client for testing
-type MockHTTPClient struct {
-	DoFunc func(req *http.Request) (*http.Response, error)
-}
-
-func (m *MockHTTPClient) RoundTrip(req *http.Request) (*http.Response, error) {
-	return m.DoFunc(req)
-}
-
 func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
-	// Helper to create HTML with specific published date
-	createHTML := func(dateStr string) string {
-		return `<html><body><span data-test-id="UnitHeader-commitTime">` + dateStr + `</span></body></html>`
-	}
-
 	// Use a fixed date that's more than 2 years old to ensure it's always detected as archived
-	oldDate := time.Now().AddDate(-3, 0, 0).Format("Jan 2, 2006")
-	recentDate := time.Now().AddDate(0, -2, 0).Format("Jan 2, 2006")
+	oldDate := time.Now().AddDate(-3, 0, 0)
+	recentDate := time.Now().AddDate(0, -2, 0)
 
 	// Date that's 8 months old - will be archived with 6-month threshold but active with default 2-year threshold
-	eightMonthsOldDate := time.Now().AddDate(0, -8, 0).Format("Jan 2, 2006")
+	eightMonthsOldDate := time.Now().AddDate(0, -8, 0)
 
 	tests := []struct {
 		name                 string
 		dependencies         []parser.Dependency
-		mockResponses        map[string]*http.Response
+		published            map[string]time.Time // Latest release of each module the proxy knows
 		expectedArchived     []string
 		expectedActive       []string
 		expectedErrors       []string
@@ -129,15 +50,9 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 				{Path: "github.com/active/repo1", Indirect: false},
 				{Path: "github.com/active/repo2", Indirect: false},
 			},
-			mockResponses: map[string]*http.Response{
-				"https://pkg.go.dev/github.com/active/repo1": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(recentDate))),
-				},
-				"https://pkg.go.dev/github.com/active/repo2": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(recentDate))),
-				},
+			published: map[string]time.Time{
+				"github.com/active/repo1": recentDate,
+				"github.com/active/repo2": recentDate,
 			},
 			expectedArchived: []string{},
 			expectedActive:   []string{"github.com/active/repo1", "github.com/active/repo2"},
@@ -149,11 +64,8 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 			dependencies: []parser.Dependency{
 				{Path: "github.com/old/repo1", Indirect: false},
 			},
-			mockResponses: map[string]*http.Response{
-				"https://pkg.go.dev/github.com/old/repo1": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(oldDate))),
-				},
+			published: map[string]time.Time{
+				"github.com/old/repo1": oldDate,
 			},
 			expectedArchived:   []string{"github.com/old/repo1"},
 			expectedActive:     []string{},
@@ -166,17 +78,12 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 			dependencies: []parser.Dependency{
 				{Path: "github.com/notfound/repo", Indirect: false},
 			},
-			mockResponses: map[string]*http.Response{
-				"https://pkg.go.dev/github.com/notfound/repo": {
-					StatusCode: 404,
-					Body:       io.NopCloser(bytes.NewBufferString("")),
-				},
-			},
+			published:          map[string]time.Time{},
 			expectedArchived:   []string{"github.com/notfound/repo"},
 			expectedActive:     []string{},
 			expectedErrors:     []string{},
 			progressCalls:      1,
-			archiveReasonCheck: map[string]string{"github.com/notfound/repo": "404 from pkg.go.dev"},
+			archiveReasonCheck: map[string]string{"github.com/notfound/repo": "404 from module proxy"},
 		},
 		{
 			name: "Mixed dependencies",
@@ -186,19 +93,9 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 				{Path: "github.com/notfound/repo", Indirect: false},
 				{Path: "github.com/indirect/repo", Indirect: true}, // Should be ignored
 			},
-			mockResponses: map[string]*http.Response{
-				"https://pkg.go.dev/github.com/active/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(recentDate))),
-				},
-				"https://pkg.go.dev/github.com/old/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(oldDate))),
-				},
-				"https://pkg.go.dev/github.com/notfound/repo": {
-					StatusCode: 404,
-					Body:       io.NopCloser(bytes.NewBufferString("")),
-				},
+			published: map[string]time.Time{
+				"github.com/active/repo": recentDate,
+				"github.com/old/repo":    oldDate,
 			},
 			expectedArchived: []string{"github.com/old/repo", "github.com/notfound/repo"},
 			expectedActive:   []string{"github.com/active/repo"},
@@ -211,15 +108,9 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 				{Path: "github.com/recent/repo", Indirect: false},   // 2 months old
 				{Path: "github.com/moderate/repo", Indirect: false}, // 8 months old - should be archived
 			},
-			mockResponses: map[string]*http.Response{
-				"https://pkg.go.dev/github.com/recent/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(recentDate))),
-				},
-				"https://pkg.go.dev/github.com/moderate/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(eightMonthsOldDate))),
-				},
+			published: map[string]time.Time{
+				"github.com/recent/repo":   recentDate,
+				"github.com/moderate/repo": eightMonthsOldDate,
 			},
 			expectedArchived:     []string{"github.com/moderate/repo"},
 			expectedActive:       []string{"github.com/recent/repo"},
@@ -235,19 +126,10 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 				{Path: "github.com/moderate/repo", Indirect: false}, // 8 months old - should be active with 1 year threshold
 				{Path: "github.com/old/repo", Indirect: false},      // 3 years old - should be archived
 			},
-			mockResponses: map[string]*http.Response{
-				"https://pkg.go.dev/github.com/recent/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(recentDate))),
-				},
-				"https://pkg.go.dev/github.com/moderate/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(eightMonthsOldDate))),
-				},
-				"https://pkg.go.dev/github.com/old/repo": {
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString(createHTML(oldDate))),
-				},
+			published: map[string]time.Time{
+				"github.com/recent/repo":   recentDate,
+				"github.com/moderate/repo": eightMonthsOldDate,
+				"github.com/old/repo":      oldDate,
 			},
 			expectedArchived:     []string{"github.com/old/repo"},
 			expectedActive:       []string{"github.com/recent/repo", "github.com/moderate/repo"},
@@ -260,27 +142,22 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Create a client with a mocked HTTP client
+			// Serve the latest release of each known module from a test proxy
+			files := make(map[string]string)
+			for path, date := range tc.published {
+				files[path+"/@v/list"] = "v1.0.0\n"
+				files[path+"/@v/v1.0.0.info"] = `{"Version":"v1.0.0","Time":"` + date.UTC().Format(time.RFC3339) + `"}`
+			}
+			proxy := newTestProxy(t, files)
+
 			client := NewClient()
+			assert.NoError(t, client.SetGoProxy(proxy.URL))
 
 			// Set custom unmaintained duration if specified
 			if tc.unmaintainedDuration > 0 {
 				client.SetUnmaintainedDuration(tc.unmaintainedDuration)
 			}
 
-			// Create a proper mock of http.Client with a mocked Transport
-			mockTransport := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					url := req.URL.String()
-					resp, ok := tc.mockResponses[url]
-					if !ok {
-						t.Fatalf("Unexpected request to %s", url)
-					}
-					return resp, nil
-				},
-			}
-			client.httpClient.Transport = mockTransport
-
 			// Track progress calls with mutex for thread safety
 			var mu sync.Mutex
 			progressCalls := 0
@@ -295,7 +172,7 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 			})
 
 			// Run the function under test
-			results := client.PingPackage(tc.dependencies)
+			results := client.PingPackage(context.Background(), tc.dependencies)
 
 			// Verify the number of progress calls
 			if progressCalls != tc.progressCalls {
@@ -310,7 +187,7 @@ func TestCheckArchivedDependenciesWithProgress(t *testing.T) {
 			for _, result := range results {
 				if result.Error != "" {
 					errorDeps = append(errorDeps, result.ModulePath)
-				} else if result.IsArchived {
+				} else if result.Status.Unmaintained() {
 					archivedDeps = append(archivedDeps, result.ModulePath)
 
 					// Check reason if specified
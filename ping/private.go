@@ -0,0 +1,57 @@
+package ping
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/mod/module"
+)
+
+// errPrivate is returned when a private module has no non-public source to be looked up from
+var errPrivate = errors.New("private module, not checked")
+
+// publicServices are the hosts that must never see the path of a private module
+var publicServices = map[string]bool{
+	"proxy.golang.org": true,
+	"pkg.go.dev":       true,
+	"index.golang.org": true,
+	"sum.golang.org":   true,
+}
+
+// PrivatePatterns holds the comma-separated glob lists that mark modules as private,
+// as described by 'go help module-private'
+type PrivatePatterns struct {
+	Private string // GOPRIVATE
+	NoProxy string // GONOPROXY, defaults to GOPRIVATE
+	NoSumDB string // GONOSUMDB, defaults to GOPRIVATE
+}
+
+// PrivatePatternsFromEnv reads the private module patterns from the environment
+func PrivatePatternsFromEnv() PrivatePatterns {
+	p := PrivatePatterns{
+		Private: os.Getenv("GOPRIVATE"),
+		NoProxy: os.Getenv("GONOPROXY"),
+		NoSumDB: os.Getenv("GONOSUMDB"),
+	}
+	// Like the go command, an empty GONOPROXY or GONOSUMDB means "same as GOPRIVATE"
+	if p.NoProxy == "" {
+		p.NoProxy = p.Private
+	}
+	if p.NoSumDB == "" {
+		p.NoSumDB = p.Private
+	}
+	return p
+}
+
+// IsPrivate reports whether a module path matches any of the private patterns
+func (p PrivatePatterns) IsPrivate(modPath string) bool {
+	return module.MatchPrefixPatterns(p.Private, modPath) ||
+		module.MatchPrefixPatterns(p.NoProxy, modPath) ||
+		module.MatchPrefixPatterns(p.NoSumDB, modPath)
+}
+
+// BypassesProxy reports whether the go command would fetch a module directly
+// from version control instead of through GOPROXY
+func (p PrivatePatterns) BypassesProxy(modPath string) bool {
+	return module.MatchPrefixPatterns(p.NoProxy, modPath)
+}
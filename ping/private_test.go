@@ -0,0 +1,105 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivatePatternsFromEnv(t *testing.T) {
+	t.Setenv("GOPRIVATE", "corp.example.com,*.internal.dev")
+	t.Setenv("GONOPROXY", "")
+	t.Setenv("GONOSUMDB", "sumless.example.com")
+
+	patterns := PrivatePatternsFromEnv()
+	assert.Equal(t, "corp.example.com,*.internal.dev", patterns.Private)
+	assert.Equal(t, "corp.example.com,*.internal.dev", patterns.NoProxy)
+	assert.Equal(t, "sumless.example.com", patterns.NoSumDB)
+}
+
+func TestPrivatePatternsMatching(t *testing.T) {
+	patterns := PrivatePatterns{
+		Private: "corp.example.com",
+		NoProxy: "none",
+		NoSumDB: "*.internal.dev",
+	}
+
+	tests := []struct {
+		modPath       string
+		private       bool
+		bypassesProxy bool
+	}{
+		{modPath: "corp.example.com/team/service", private: true},
+		{modPath: "corp.example.com", private: true},
+		{modPath: "git.internal.dev/lib", private: true},
+		{modPath: "github.com/corp.example.com/lib", private: false},
+		{modPath: "github.com/pkg/errors", private: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modPath, func(t *testing.T) {
+			assert.Equal(t, tt.private, patterns.IsPrivate(tt.modPath))
+			assert.Equal(t, tt.bypassesProxy, patterns.BypassesProxy(tt.modPath))
+		})
+	}
+}
+
+func TestCheckProxyStatusPrivateModules(t *testing.T) {
+	corpProxy := newTestProxy(t, map[string]string{
+		"corp.example.com/lib/@v/list":        "v1.4.0\n",
+		"corp.example.com/lib/@v/v1.4.0.info": `{"Version":"v1.4.0","Time":"2024-03-05T10:30:00Z"}`,
+	})
+
+	t.Run("Excluded from proxies", func(t *testing.T) {
+		client := NewClient()
+		assert.NoError(t, client.SetGoProxy(corpProxy.URL))
+		client.SetPrivatePatterns(PrivatePatterns{Private: "corp.example.com", NoProxy: "corp.example.com"})
+
+		_, err := client.checkProxyStatus(context.Background(), "corp.example.com/lib")
+		assert.ErrorIs(t, err, errPrivate)
+	})
+
+	t.Run("Rerouted to non-public proxy", func(t *testing.T) {
+		client := NewClient()
+		assert.NoError(t, client.SetGoProxy("https://proxy.golang.org,"+corpProxy.URL+",direct"))
+		client.SetPrivatePatterns(PrivatePatterns{Private: "corp.example.com", NoProxy: "none"})
+
+		result, err := client.checkProxyStatus(context.Background(), "corp.example.com/lib")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "v1.4.0", result.Version)
+	})
+
+	t.Run("Only public sources configured", func(t *testing.T) {
+		client := NewClient()
+		client.SetPrivatePatterns(PrivatePatterns{NoSumDB: "corp.example.com"})
+		client.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatalf("Private module sent to %s", req.URL)
+			return nil, nil
+		})
+
+		_, err := client.checkProxyStatus(context.Background(), "corp.example.com/lib")
+		assert.ErrorIs(t, err, errPrivate)
+	})
+}
+
+func TestPingPackagePrivateModules(t *testing.T) {
+	client := NewClient()
+	client.SetPrivatePatterns(PrivatePatterns{Private: "corp.example.com", NoProxy: "corp.example.com"})
+
+	progress := make(map[string]string)
+	client.SetProgressCallback(func(dependency string, status string) {
+		progress[dependency] = status
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "corp.example.com/team/service"}})
+
+	assert.Len(t, results, 1)
+	assert.True(t, results[0].Private)
+	assert.Equal(t, StatusUnknown, results[0].Status)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, "Private (Not checked)", progress["corp.example.com/team/service"])
+}
@@ -0,0 +1,361 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// DefaultGoProxy is the GOPROXY value used by the go command when none is set
+const DefaultGoProxy = "https://proxy.golang.org,direct"
+
+// errProxyOff is returned when the GOPROXY list disallows module lookups
+var errProxyOff = errors.New("module lookup disabled by GOPROXY=off")
+
+// errDirect is returned when no module proxy answered and only "direct" is left, since
+// godeping doesn't fetch modules from their version control repositories
+var errDirect = errors.New("no module proxy to look up the module, GOPROXY=direct is not supported")
+
+// ProxyInfo is the version metadata served by a module proxy
+// (the JSON body of the @latest and @v/<version>.info endpoints)
+type ProxyInfo struct {
+	Version string
+	Time    time.Time
+}
+
+// proxySpec is a single entry of a GOPROXY list
+type proxySpec struct {
+	url string // base URL, or one of the keywords "direct" and "off"
+
+	// fallBackOnError is true if the entry is followed by a pipe,
+	// meaning any error moves on to the next entry; after a comma
+	// only 404 and 410 responses do.
+	fallBackOnError bool
+}
+
+// proxyError is returned when a proxy answers with a non-200 status code
+type proxyError struct {
+	url        string
+	statusCode int
+}
+
+func (e *proxyError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d", e.url, e.statusCode)
+}
+
+// isNotFound reports whether err is a 404 or 410 answer from a proxy
+func isNotFound(err error) bool {
+	var pe *proxyError
+	return errors.As(err, &pe) && (pe.statusCode == http.StatusNotFound || pe.statusCode == http.StatusGone)
+}
+
+// parseGoProxy splits a GOPROXY value into its entries following the rules
+// described by 'go help goproxy'
+func parseGoProxy(value string) ([]proxySpec, error) {
+	if strings.TrimSpace(value) == "" {
+		value = DefaultGoProxy
+	}
+
+	var specs []proxySpec
+	for value != "" {
+		var entry string
+		fallBackOnError := false
+		if i := strings.IndexAny(value, ",|"); i >= 0 {
+			entry = value[:i]
+			fallBackOnError = value[i] == '|'
+			value = value[i+1:]
+		} else {
+			entry, value = value, ""
+		}
+
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		switch entry {
+		case "direct", "off":
+		default:
+			// Like the go command, assume https for host-only entries
+			if !strings.Contains(entry, ":/") {
+				entry = "https://" + entry
+			}
+			u, err := url.Parse(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid GOPROXY entry %q: %v", entry, err)
+			}
+			if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "file" {
+				return nil, fmt.Errorf("invalid GOPROXY entry %q: unsupported scheme %q", entry, u.Scheme)
+			}
+			entry = strings.TrimSuffix(entry, "/")
+		}
+
+		specs = append(specs, proxySpec{url: entry, fallBackOnError: fallBackOnError})
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("GOPROXY list is empty")
+	}
+
+	return specs, nil
+}
+
+// checkProxyStatus walks the GOPROXY list to find the latest version of a module.
+// The "direct" keyword is skipped, since godeping cannot talk to version control
+// systems itself; errDirect is returned if nothing else answered. Private modules are
+// only sent to proxies that are not public services, and errPrivate is returned if
+// there are none.
+func (c *Client) checkProxyStatus(ctx context.Context, modPath string) (lookupResult, error) {
+	// Nothing leaves the machine in offline mode, so private modules can be looked up too
+	private := !c.offline && c.private.IsPrivate(modPath)
+	if !c.offline && c.private.BypassesProxy(modPath) {
+		return lookupResult{}, errPrivate
+	}
+
+	tried := false
+	notFound := false
+	direct := false
+	var lastErr error
+	for _, proxy := range c.proxies {
+		if private && (proxy.url == "direct" || publicServices[proxyHost(proxy.url)]) {
+			continue
+		}
+		tried = true
+
+		switch proxy.url {
+		case "off":
+			if notFound {
+				return lookupResult{StatusCode: http.StatusNotFound, Source: "module proxy"}, nil
+			}
+			return lookupResult{}, errProxyOff
+		case "direct":
+			direct = true
+			continue
+		}
+
+		info, versions, err := c.latestFromProxy(ctx, proxy.url, modPath)
+		if err == nil {
+			return lookupResult{
+				StatusCode: http.StatusOK,
+				Version:    info.Version,
+				Published:  info.Time,
+				Versions:   versions,
+				Source:     proxyHost(proxy.url),
+				proxyURL:   proxy.url,
+			}, nil
+		}
+
+		if isNotFound(err) {
+			notFound = true
+			continue
+		}
+		if !proxy.fallBackOnError {
+			return lookupResult{}, err
+		}
+		lastErr = err
+	}
+
+	if !tried {
+		return lookupResult{}, errPrivate
+	}
+	if !notFound && lastErr != nil {
+		return lookupResult{}, lastErr
+	}
+	if !notFound && direct {
+		return lookupResult{}, errDirect
+	}
+
+	return lookupResult{StatusCode: http.StatusNotFound, Source: "module proxy"}, nil
+}
+
+// latestFromProxy asks a single proxy for the latest version of a module and its timestamp,
+// along with every tagged version it knows. Like the go command it prefers the highest release
+// listed in @v/list, then the highest pre-release, and only falls back to @latest
+// (a pseudo-version) when nothing is tagged.
+func (c *Client) latestFromProxy(ctx context.Context, proxyURL, modPath string) (ProxyInfo, []string, error) {
+	data, err := c.proxyGet(ctx, proxyURL, modPath, "@v/list")
+	if isNotFound(err) && strings.HasPrefix(proxyURL, "file://") {
+		// go mod download doesn't write @v/list to the module cache, only the files of each version
+		data, err = fileListVersions(proxyURL, modPath)
+	}
+	if err != nil {
+		return ProxyInfo{}, nil, err
+	}
+
+	versions := strings.Fields(string(data))
+	latest := latestVersion(versions)
+	if latest != "" {
+		info, err := c.infoFromProxy(ctx, proxyURL, modPath, latest)
+		return info, versions, err
+	}
+
+	data, err = c.proxyGet(ctx, proxyURL, modPath, "@latest")
+	if err != nil {
+		return ProxyInfo{}, nil, err
+	}
+	info, err := decodeProxyInfo(modPath, data)
+	return info, versions, err
+}
+
+// infoFromProxy fetches the metadata of a single module version from a proxy
+func (c *Client) infoFromProxy(ctx context.Context, proxyURL, modPath, version string) (ProxyInfo, error) {
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return ProxyInfo{}, err
+	}
+
+	data, err := c.proxyGet(ctx, proxyURL, modPath, "@v/"+escaped+".info")
+	if err != nil {
+		return ProxyInfo{}, err
+	}
+	return decodeProxyInfo(modPath, data)
+}
+
+// decodeProxyInfo parses the JSON body of an .info or @latest response
+func decodeProxyInfo(modPath string, data []byte) (ProxyInfo, error) {
+	var info ProxyInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return ProxyInfo{}, fmt.Errorf("invalid version info for %s: %v", modPath, err)
+	}
+	return info, nil
+}
+
+// goModFromProxy fetches and parses the go.mod file of a module version from a proxy
+func (c *Client) goModFromProxy(ctx context.Context, proxyURL, modPath, version string) (*parser.ModuleInfo, error) {
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.proxyGet(ctx, proxyURL, modPath, "@v/"+escaped+".mod")
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.ParseDependencyGoMod(modPath+"@"+version+"/go.mod", data)
+}
+
+// proxyGet fetches <proxyURL>/<escaped module path>/<suffix>
+func (c *Client) proxyGet(ctx context.Context, proxyURL, modPath, suffix string) ([]byte, error) {
+	target, err := proxyResourceURL(proxyURL, modPath, suffix)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(target, "file://") {
+		return fileGet(target)
+	}
+
+	_, source, _ := strings.Cut(proxyURL, "://")
+	statusCode, body, err := c.cachedGet(ctx, source, modPath, suffix, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, &proxyError{url: target, statusCode: statusCode}
+	}
+
+	return body, nil
+}
+
+// proxyResourceURL returns the URL of a resource of a module on a proxy
+func proxyResourceURL(proxyURL, modPath, suffix string) (string, error) {
+	escaped, err := module.EscapePath(modPath)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s", proxyURL, escaped, suffix), nil
+}
+
+// versionResourceURL returns the URL of the .info or .mod file of a module version on a proxy
+func versionResourceURL(proxyURL, modPath, version, ext string) string {
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return ""
+	}
+	target, _ := proxyResourceURL(proxyURL, modPath, "@v/"+escaped+ext)
+	return target
+}
+
+// fileGet reads a file:// proxy URL from disk, reporting missing files like a 404
+func fileGet(target string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.FromSlash(strings.TrimPrefix(target, "file://")))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &proxyError{url: target, statusCode: http.StatusNotFound}
+	}
+	return data, err
+}
+
+// fileListVersions lists the versions a file:// proxy has an .info file for, in the format of
+// @v/list. It is used when the list itself is missing, as in a module cache filled by go mod download.
+func fileListVersions(proxyURL, modPath string) ([]byte, error) {
+	target, err := proxyResourceURL(proxyURL, modPath, "@v")
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.FromSlash(strings.TrimPrefix(target, "file://")))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &proxyError{url: target + "/list", statusCode: http.StatusNotFound}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".info")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if version, err := module.UnescapeVersion(name); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, &proxyError{url: target + "/list", statusCode: http.StatusNotFound}
+	}
+	return []byte(strings.Join(versions, "\n")), nil
+}
+
+// latestVersion returns the highest release in versions,
+// or the highest pre-release if there are no releases
+func latestVersion(versions []string) string {
+	var latestRelease, latestPrerelease string
+	for _, v := range versions {
+		if !semver.IsValid(v) {
+			continue
+		}
+		if semver.Prerelease(v) == "" {
+			if latestRelease == "" || semver.Compare(v, latestRelease) > 0 {
+				latestRelease = v
+			}
+		} else if latestPrerelease == "" || semver.Compare(v, latestPrerelease) > 0 {
+			latestPrerelease = v
+		}
+	}
+
+	if latestRelease != "" {
+		return latestRelease
+	}
+	return latestPrerelease
+}
+
+// proxyHost returns the host name of a proxy URL for use in messages
+func proxyHost(proxyURL string) string {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return proxyURL
+	}
+	return u.Host
+}
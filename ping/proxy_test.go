@@ -0,0 +1,430 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProxy is a module proxy stub that counts the requests it gets
+type testProxy struct {
+	*httptest.Server
+	count int32
+}
+
+// requests returns the number of requests the proxy got so far
+func (p *testProxy) requests() int32 {
+	return atomic.LoadInt32(&p.count)
+}
+
+// newTestProxy starts a module proxy serving the given files, keyed by the
+// request path without the leading slash (e.g. "github.com/a/b/@v/list")
+func newTestProxy(t *testing.T, files map[string]string) *testProxy {
+	t.Helper()
+	proxy := &testProxy{}
+	proxy.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxy.count, 1)
+		body, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy
+}
+
+// newFailingProxy starts a module proxy that answers every request with statusCode
+func newFailingProxy(t *testing.T, statusCode int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseGoProxy(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []proxySpec
+		expectError bool
+	}{
+		{
+			name:  "Empty value uses default",
+			value: "",
+			expected: []proxySpec{
+				{url: "https://proxy.golang.org"},
+				{url: "direct"},
+			},
+		},
+		{
+			name:  "Comma and pipe separators",
+			value: "https://a.example.com|https://b.example.com/,direct",
+			expected: []proxySpec{
+				{url: "https://a.example.com", fallBackOnError: true},
+				{url: "https://b.example.com"},
+				{url: "direct"},
+			},
+		},
+		{
+			name:  "Host without scheme",
+			value: "goproxy.example.com,off",
+			expected: []proxySpec{
+				{url: "https://goproxy.example.com"},
+				{url: "off"},
+			},
+		},
+		{
+			name:        "Unsupported scheme",
+			value:       "ftp://proxy.example.com",
+			expectError: true,
+		},
+		{
+			name:        "Only separators",
+			value:       ",|,",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := parseGoProxy(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, specs)
+		})
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	assert.Equal(t, "v1.2.0", latestVersion([]string{"v1.0.0", "v1.2.0", "v1.10.0-rc.1", "v1.1.5"}))
+	assert.Equal(t, "v2.0.0-beta.2", latestVersion([]string{"v2.0.0-beta.1", "v2.0.0-beta.2"}))
+	assert.Equal(t, "v1.10.0", latestVersion([]string{"v1.9.0", "v1.10.0", "garbage"}))
+	assert.Equal(t, "", latestVersion(nil))
+}
+
+func TestCheckProxyStatus(t *testing.T) {
+	published := time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC)
+
+	found := newTestProxy(t, map[string]string{
+		"github.com/example/pkg/@v/list":          "v1.0.0\nv1.1.0\nv1.2.0-rc.1\n",
+		"github.com/example/pkg/@v/v1.1.0.info":   `{"Version":"v1.1.0","Time":"2024-03-05T10:30:00Z"}`,
+		"github.com/example/untagged/@v/list":     "",
+		"github.com/example/untagged/@latest":     `{"Version":"v0.0.0-20240305103000-abcdef123456","Time":"2024-03-05T10:30:00Z"}`,
+		"github.com/!azure/go-sdk/@v/list":        "v0.3.0\n",
+		"github.com/!azure/go-sdk/@v/v0.3.0.info": `{"Version":"v0.3.0","Time":"2024-03-05T10:30:00Z"}`,
+	})
+	empty := newTestProxy(t, nil)
+	broken := newFailingProxy(t, http.StatusInternalServerError)
+
+	tests := []struct {
+		name           string
+		goproxy        string
+		modPath        string
+		expectError    bool
+		expectedStatus int
+		expectedInfo   string
+	}{
+		{
+			name:           "Latest release is preferred over pre-release",
+			goproxy:        found.URL,
+			modPath:        "github.com/example/pkg",
+			expectedStatus: http.StatusOK,
+			expectedInfo:   "v1.1.0",
+		},
+		{
+			name:           "Untagged module falls back to @latest",
+			goproxy:        found.URL,
+			modPath:        "github.com/example/untagged",
+			expectedStatus: http.StatusOK,
+			expectedInfo:   "v0.0.0-20240305103000-abcdef123456",
+		},
+		{
+			name:           "Upper case letters are escaped",
+			goproxy:        found.URL,
+			modPath:        "github.com/Azure/go-sdk",
+			expectedStatus: http.StatusOK,
+			expectedInfo:   "v0.3.0",
+		},
+		{
+			name:           "Comma falls back on not found",
+			goproxy:        empty.URL + "," + found.URL,
+			modPath:        "github.com/example/pkg",
+			expectedStatus: http.StatusOK,
+			expectedInfo:   "v1.1.0",
+		},
+		{
+			name:        "Comma stops on server error",
+			goproxy:     broken.URL + "," + found.URL,
+			modPath:     "github.com/example/pkg",
+			expectError: true,
+		},
+		{
+			name:           "Pipe falls back on server error",
+			goproxy:        broken.URL + "|" + found.URL,
+			modPath:        "github.com/example/pkg",
+			expectedStatus: http.StatusOK,
+			expectedInfo:   "v1.1.0",
+		},
+		{
+			name:           "Not found on every proxy",
+			goproxy:        empty.URL + "," + found.URL,
+			modPath:        "github.com/example/missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Off disables lookups",
+			goproxy:     "off",
+			modPath:     "github.com/example/pkg",
+			expectError: true,
+		},
+		{
+			name:           "Direct is skipped after not found",
+			goproxy:        empty.URL + ",direct",
+			modPath:        "github.com/example/missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Direct alone cannot look up",
+			goproxy:     "direct",
+			modPath:     "github.com/example/pkg",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient()
+			client.SetRetryPolicy(RetryPolicy{})
+			assert.NoError(t, client.SetGoProxy(tt.goproxy))
+			// Only the test proxies are asked, never pkg.go.dev or a repository
+			client.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Hostname() != "127.0.0.1" {
+					t.Errorf("Unexpected request to %s", req.URL)
+				}
+				return http.DefaultTransport.RoundTrip(req)
+			})
+
+			result, err := client.checkProxyStatus(context.Background(), tt.modPath)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, result.StatusCode)
+			assert.Equal(t, tt.expectedInfo, result.Version)
+			if tt.expectedInfo != "" {
+				assert.Equal(t, published, result.Published)
+			}
+		})
+	}
+}
+
+func TestPingPackageWithProxy(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	old := time.Now().AddDate(-3, 0, 0).UTC().Format(time.RFC3339)
+
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/active/repo/@v/list":        "v1.0.0\n",
+		"github.com/active/repo/@v/v1.0.0.info": `{"Version":"v1.0.0","Time":"` + recent + `"}`,
+		"github.com/old/repo/@v/list":           "v0.1.0\n",
+		"github.com/old/repo/@v/v0.1.0.info":    `{"Version":"v0.1.0","Time":"` + old + `"}`,
+	})
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL+",off"))
+
+	var mu sync.Mutex
+	progress := make(map[string]string)
+	client.SetProgressCallback(func(dependency string, status string) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress[dependency] = status
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/active/repo"},
+		{Path: "github.com/old/repo"},
+		{Path: "github.com/missing/repo"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	assert.Equal(t, StatusActive, byPath["github.com/active/repo"].Status)
+	assert.Equal(t, "v1.0.0", byPath["github.com/active/repo"].LatestVersion)
+
+	assert.Equal(t, StatusStale, byPath["github.com/old/repo"].Status)
+	assert.Contains(t, byPath["github.com/old/repo"].Reason, "Not updated since")
+
+	assert.Equal(t, StatusNotFound, byPath["github.com/missing/repo"].Status)
+	assert.Equal(t, "404 from module proxy", byPath["github.com/missing/repo"].Reason)
+	assert.Contains(t, progress["github.com/missing/repo"], "Not found")
+}
+
+func TestPingPackageDeprecatedModule(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/old/lib/@v/list":        "v1.0.0\nv1.1.0\n",
+		"github.com/old/lib/@v/v1.1.0.info": `{"Version":"v1.1.0","Time":"` + recent + `"}`,
+		"github.com/old/lib/@v/v1.1.0.mod":  "// Deprecated: use github.com/new/lib instead.\nmodule github.com/old/lib\n",
+		"github.com/new/lib/@v/list":        "v2.0.0\n",
+		"github.com/new/lib/@v/v2.0.0.info": `{"Version":"v2.0.0","Time":"` + recent + `"}`,
+		"github.com/new/lib/@v/v2.0.0.mod":  "module github.com/new/lib\n\ngo 1.22\n",
+	})
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+
+	progress := make(map[string]string)
+	var mu sync.Mutex
+	client.SetProgressCallback(func(dependency string, status string) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress[dependency] = status
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/old/lib", Version: "v1.1.0"},
+		{Path: "github.com/new/lib", Version: "v2.0.0"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	assert.Equal(t, "use github.com/new/lib instead.", byPath["github.com/old/lib"].Deprecated)
+	assert.Equal(t, "Deprecated: use github.com/new/lib instead.", byPath["github.com/old/lib"].Reason)
+	assert.Equal(t, "Deprecated (use github.com/new/lib instead.)", progress["github.com/old/lib"])
+	assert.Equal(t, StatusDeprecated, byPath["github.com/old/lib"].Status)
+	assert.Empty(t, byPath["github.com/new/lib"].Deprecated)
+	assert.Equal(t, StatusActive, byPath["github.com/new/lib"].Status)
+	assert.Contains(t, progress["github.com/new/lib"], "Active")
+}
+
+func TestPingPackageRetractedVersion(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/@v/list":        "v1.0.0\nv1.0.1\nv1.1.0\n",
+		"github.com/example/lib/@v/v1.1.0.info": `{"Version":"v1.1.0","Time":"` + recent + `"}`,
+		"github.com/example/lib/@v/v1.1.0.mod":  "module github.com/example/lib\n\nretract v1.0.1 // Breaks on Windows.\n",
+	})
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+
+	var progress string
+	client.SetProgressCallback(func(dependency string, status string) {
+		progress = status
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib", Version: "v1.0.1"}})
+	assert.Len(t, results, 1)
+	assert.Equal(t, "v1.0.1", results[0].Version)
+	assert.True(t, results[0].Retracted)
+	assert.Equal(t, "Breaks on Windows.", results[0].RetractRationale)
+	assert.Equal(t, StatusActive, results[0].Status)
+	assert.Contains(t, progress, "v1.0.1 retracted")
+
+	results = client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib", Version: "v1.0.0"}})
+	assert.Len(t, results, 1)
+	assert.False(t, results[0].Retracted)
+}
+
+func TestPingPackagePseudoVersionFallback(t *testing.T) {
+	broken := newFailingProxy(t, http.StatusInternalServerError)
+
+	client := NewClient()
+	client.SetRetryPolicy(RetryPolicy{})
+	assert.NoError(t, client.SetGoProxy(broken.URL))
+
+	progress := make(map[string]string)
+	var mu sync.Mutex
+	client.SetProgressCallback(func(dependency string, status string) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress[dependency] = status
+	})
+
+	recent := time.Now().AddDate(0, -1, 0).UTC()
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/old", Version: "v0.0.0-20190102030405-abcdef123456"},
+		{Path: "github.com/example/recent", Version: "v1.2.4-0." + recent.Format("20060102150405") + "-abcdef123456"},
+		{Path: "github.com/example/tagged", Version: "v1.0.0"},
+	})
+
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+
+	old := byPath["github.com/example/old"]
+	assert.Equal(t, StatusStale, old.Status)
+	assert.True(t, old.PublishedEstimated)
+	assert.Equal(t, time.Date(2019, time.January, 2, 3, 4, 5, 0, time.UTC), old.LastPublished)
+	assert.Equal(t, old.LastPublished, old.VersionPublished)
+	assert.NotEmpty(t, old.Error)
+	assert.Equal(t, "Stale (Last commit: Jan 2, 2019 from pseudo-version)", progress["github.com/example/old"])
+
+	assert.Equal(t, StatusActive, byPath["github.com/example/recent"].Status)
+	assert.Contains(t, progress["github.com/example/recent"], "Active (Last commit: ")
+
+	tagged := byPath["github.com/example/tagged"]
+	assert.False(t, tagged.PublishedEstimated)
+	assert.Equal(t, StatusUnknown, tagged.Status)
+	assert.True(t, strings.HasPrefix(progress["github.com/example/tagged"], "Error: "))
+}
+
+func TestUnmaintainedDurationFor(t *testing.T) {
+	client := NewClient()
+	client.SetUnmaintainedDuration(time.Hour)
+	client.SetUnmaintainedDurationFor("github.com/golang", 2*time.Hour)
+	client.SetUnmaintainedDurationFor("github.com/golang/protobuf", 3*time.Hour)
+	client.SetUnmaintainedDurationFor("golang.org/x/*", 4*time.Hour)
+
+	assert.Equal(t, time.Hour, client.unmaintainedDurationOf("github.com/pkg/errors"))
+	assert.Equal(t, 2*time.Hour, client.unmaintainedDurationOf("github.com/golang/mock"))
+	assert.Equal(t, 3*time.Hour, client.unmaintainedDurationOf("github.com/golang/protobuf"))
+	assert.Equal(t, 4*time.Hour, client.unmaintainedDurationOf("golang.org/x/net"))
+
+	old := time.Now().AddDate(-3, 0, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/golang/protobuf/@v/list":        "v1.5.4\n",
+		"github.com/golang/protobuf/@v/v1.5.4.info": `{"Version":"v1.5.4","Time":"` + old + `"}`,
+		"github.com/golang/mock/@v/list":            "v1.6.0\n",
+		"github.com/golang/mock/@v/v1.6.0.info":     `{"Version":"v1.6.0","Time":"` + old + `"}`,
+	})
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetUnmaintainedDuration(2 * 365 * 24 * time.Hour)
+	client.SetUnmaintainedDurationFor("github.com/golang/protobuf", 5*365*24*time.Hour)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/golang/protobuf", Version: "v1.5.4"},
+		{Path: "github.com/golang/mock", Version: "v1.6.0"},
+	})
+	byPath := make(map[string]RepoStatus)
+	for _, result := range results {
+		byPath[result.ModulePath] = result
+	}
+	assert.Equal(t, StatusActive, byPath["github.com/golang/protobuf"].Status)
+	assert.Equal(t, StatusStale, byPath["github.com/golang/mock"].Status)
+}
@@ -0,0 +1,77 @@
+package ping
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the default number of requests per second sent to any single host
+const DefaultRateLimit = 20
+
+// DefaultConcurrency is the default number of dependencies checked at the same time
+const DefaultConcurrency = 10
+
+// hostLimiter spaces out requests to each host so that none receives more than
+// a given number per second. A single limiter is shared by all checks, so the
+// limit holds no matter how many run concurrently.
+type hostLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time // Earliest time the next request to a host may start
+}
+
+func newHostLimiter(perSecond float64) *hostLimiter {
+	return &hostLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		next:     make(map[string]time.Time),
+	}
+}
+
+// wait blocks until a request to host may be sent, or ctx is done
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// SetRateLimit limits the requests sent to each host to perSecond, across all
+// concurrent checks. Zero or less removes the limit.
+func (c *Client) SetRateLimit(perSecond float64) {
+	if perSecond <= 0 {
+		c.transport().limiter = nil
+		return
+	}
+	c.transport().limiter = newHostLimiter(perSecond)
+}
+
+// SetConcurrency sets how many dependencies are checked at the same time
+func (c *Client) SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	c.concurrency = n
+}
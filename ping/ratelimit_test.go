@@ -0,0 +1,109 @@
+package ping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostLimiter(t *testing.T) {
+	limiter := newHostLimiter(50) // one request every 20ms
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, limiter.wait(context.Background(), "proxy.golang.org"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// Other hosts have their own budget: the request takes the slot at the time of the
+	// call, not one queued behind proxy.golang.org
+	before := time.Now()
+	assert.NoError(t, limiter.wait(context.Background(), "goproxy.example.com"))
+	after := time.Now()
+	limiter.mu.Lock()
+	next := limiter.next["goproxy.example.com"]
+	limiter.mu.Unlock()
+	assert.WithinRange(t, next, before.Add(limiter.interval), after.Add(limiter.interval))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		limiter.wait(context.Background(), "pkg.go.dev")
+	}
+	assert.ErrorIs(t, limiter.wait(ctx, "pkg.go.dev"), context.Canceled)
+
+	var unlimited *hostLimiter
+	assert.NoError(t, unlimited.wait(context.Background(), "pkg.go.dev"))
+}
+
+func TestSetRateLimit(t *testing.T) {
+	server := newTestProxy(t, map[string]string{"ok": "ok"})
+
+	client := NewClient()
+	client.SetRateLimit(20) // one request every 50ms
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.httpClient.Get(server.URL + "/ok")
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	assert.Equal(t, int32(5), server.requests())
+
+	client.SetRateLimit(0)
+	assert.Nil(t, client.transport().limiter)
+}
+
+func TestSetConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client := NewClient()
+	client.SetRateLimit(0)
+	client.SetConcurrency(2)
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	var deps []parser.Dependency
+	for i := 0; i < 8; i++ {
+		deps = append(deps, parser.Dependency{Path: fmt.Sprintf("github.com/example/lib%d", i)})
+	}
+	results := client.PingPackage(context.Background(), deps)
+
+	assert.Len(t, results, 8)
+	assert.Equal(t, 2, maxInFlight)
+
+	client.SetConcurrency(0)
+	assert.Equal(t, 1, client.concurrency)
+}
@@ -0,0 +1,188 @@
+package ping
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt, 0 disables retrying
+	BaseDelay  time.Duration // Delay before the first retry, doubled for every further one
+	MaxDelay   time.Duration // Upper bound for a single delay, including Retry-After and rate limit resets
+}
+
+// DefaultRetryPolicy retries a few times over roughly ten seconds
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// retryCounterKey is the context key of the *int32 counting retries for a dependency
+type retryCounterKey struct{}
+
+// withRetryCounter returns a context whose requests add their retries to counter
+func withRetryCounter(ctx context.Context, counter *int32) context.Context {
+	return context.WithValue(ctx, retryCounterKey{}, counter)
+}
+
+// retryTransport retries idempotent requests that failed for reasons likely to go away:
+// network errors, rate limits and 5xx server errors other than 501.
+// It backs off exponentially with full jitter and honours Retry-After and GitHub's rate limit reset time.
+// Every attempt also waits for its turn with the rate limiter, if any.
+type retryTransport struct {
+	base    http.RoundTripper
+	policy  RetryPolicy
+	limiter *hostLimiter
+}
+
+// SetRetryPolicy sets how requests that fail transiently are retried
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.transport().policy = policy
+}
+
+// transport returns the client's retrying transport, wrapping whatever
+// transport is currently set (without retries) if there is none
+func (c *Client) transport() *retryTransport {
+	if rt, ok := c.httpClient.Transport.(*retryTransport); ok {
+		return rt
+	}
+	rt := &retryTransport{base: c.httpClient.Transport}
+	c.httpClient.Transport = rt
+	return rt
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		if err := t.limiter.wait(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+		return base.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+
+		resp, err := base.RoundTrip(req)
+		if attempt >= t.policy.MaxRetries || !retryable(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > t.policy.MaxDelay {
+					// The server asked us to wait longer than we are willing to
+					return resp, err
+				}
+				delay = after
+			}
+			resp.Body.Close()
+		}
+
+		if counter, ok := req.Context().Value(retryCounterKey{}).(*int32); ok {
+			atomic.AddInt32(counter, 1)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns a random delay of up to BaseDelay*2^attempt, capped at MaxDelay
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.policy.BaseDelay << attempt
+	if delay <= 0 || delay > t.policy.MaxDelay {
+		delay = t.policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// retryable reports whether a request that got resp or err is worth trying again
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		// A host that doesn't exist won't appear on the next attempt
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusForbidden:
+		// GitHub reports secondary rate limits as 403 with a Retry-After header,
+		// and an exhausted primary rate limit with the time it resets at
+		_, exhausted := rateLimitReset(resp)
+		return resp.Header.Get("Retry-After") != "" || exhausted
+	case resp.StatusCode == http.StatusNotImplemented:
+		return false
+	default:
+		return resp.StatusCode >= 500
+	}
+}
+
+// retryAfter returns how long the server asked to wait before trying again, from the
+// Retry-After header, given either in seconds or as an HTTP date, or else from the
+// X-RateLimit-Reset time (in Unix seconds) of an exhausted GitHub rate limit
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return rateLimitReset(resp)
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// rateLimitReset returns how long until a rate limit reported as exhausted by the
+// X-RateLimit-Remaining and X-RateLimit-Reset headers resets
+func rateLimitReset(resp *http.Response) (time.Duration, bool) {
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(time.Unix(reset, 0))
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
@@ -0,0 +1,255 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedResponse is one answer of a newScriptedServer
+type scriptedResponse struct {
+	statusCode int
+	retryAfter string
+	header     http.Header
+	body       string
+}
+
+// newScriptedServer answers requests with the given responses in order,
+// repeating the last one once the script runs out
+func newScriptedServer(t *testing.T, script ...scriptedResponse) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&requests, 1)) - 1
+		if n >= len(script) {
+			n = len(script) - 1
+		}
+		resp := script[n]
+		if resp.retryAfter != "" {
+			w.Header().Set("Retry-After", resp.retryAfter)
+		}
+		for name, values := range resp.header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(resp.statusCode)
+		w.Write([]byte(resp.body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// fastRetries retries quickly so that tests don't wait
+var fastRetries = RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name             string
+		script           []scriptedResponse
+		expectedStatus   int
+		expectedRequests int32
+	}{
+		{
+			name:             "Transient server errors",
+			script:           []scriptedResponse{{statusCode: 503}, {statusCode: 502}, {statusCode: 200}},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "Rate limited with Retry-After",
+			script:           []scriptedResponse{{statusCode: 429, retryAfter: "0"}, {statusCode: 200}},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			name:             "Retry-After longer than the maximum delay",
+			script:           []scriptedResponse{{statusCode: 429, retryAfter: "3600"}, {statusCode: 200}},
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Secondary rate limit",
+			script:           []scriptedResponse{{statusCode: 403, retryAfter: "0"}, {statusCode: 200}},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			name: "Primary rate limit",
+			script: []scriptedResponse{{statusCode: 403, header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Unix(), 10)},
+			}}, {statusCode: 200}},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			name: "Primary rate limit resetting after the maximum delay",
+			script: []scriptedResponse{{statusCode: 429, header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+			}}, {statusCode: 200}},
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Forbidden with remaining rate limit",
+			script:           []scriptedResponse{{statusCode: 403, header: http.Header{"X-Ratelimit-Remaining": {"12"}, "X-Ratelimit-Reset": {"0"}}}, {statusCode: 200}},
+			expectedStatus:   http.StatusForbidden,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Forbidden",
+			script:           []scriptedResponse{{statusCode: 403}, {statusCode: 200}},
+			expectedStatus:   http.StatusForbidden,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Not found is final",
+			script:           []scriptedResponse{{statusCode: 404}, {statusCode: 200}},
+			expectedStatus:   http.StatusNotFound,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Not implemented is final",
+			script:           []scriptedResponse{{statusCode: 501}, {statusCode: 200}},
+			expectedStatus:   http.StatusNotImplemented,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Gives up after the maximum retries",
+			script:           []scriptedResponse{{statusCode: 500}},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedRequests: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newScriptedServer(t, tt.script...)
+			client := &http.Client{Transport: &retryTransport{policy: fastRetries}}
+
+			var retries int32
+			req, err := http.NewRequestWithContext(withRetryCounter(context.Background(), &retries), http.MethodGet, server.URL, nil)
+			assert.NoError(t, err)
+
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedRequests, atomic.LoadInt32(requests))
+			assert.Equal(t, tt.expectedRequests-1, atomic.LoadInt32(&retries))
+		})
+	}
+}
+
+func TestRetryTransportNetworkError(t *testing.T) {
+	server, _ := newScriptedServer(t, scriptedResponse{statusCode: 200})
+	addr := server.Listener.Addr().String()
+	server.Close()
+
+	var retries int32
+	client := &http.Client{Transport: &retryTransport{policy: fastRetries}}
+	req, _ := http.NewRequestWithContext(withRetryCounter(context.Background(), &retries), http.MethodGet, "http://"+addr, nil)
+
+	_, err := client.Do(req)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&retries))
+}
+
+func TestRetryTransportCancelled(t *testing.T) {
+	server, requests := newScriptedServer(t, scriptedResponse{statusCode: 503})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &http.Client{Transport: &retryTransport{policy: RetryPolicy{MaxRetries: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := client.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	_, ok := retryAfter(resp)
+	assert.False(t, ok)
+
+	resp.Header.Set("Retry-After", "120")
+	d, ok := retryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, d)
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	d, ok = retryAfter(resp)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Minute.Seconds(), d.Seconds(), 2)
+
+	resp.Header.Set("Retry-After", "soon")
+	_, ok = retryAfter(resp)
+	assert.False(t, ok)
+
+	resp.Header = http.Header{}
+	resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+	_, ok = retryAfter(resp)
+	assert.False(t, ok, "Rate limit reset without an exhausted limit")
+
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	d, ok = retryAfter(resp)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Minute.Seconds(), d.Seconds(), 2)
+}
+
+func TestBackoff(t *testing.T) {
+	rt := &retryTransport{policy: RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}}
+	for attempt := 0; attempt < 10; attempt++ {
+		limit := 100 * time.Millisecond << attempt
+		if limit > time.Second {
+			limit = time.Second
+		}
+		d := rt.backoff(attempt)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, limit)
+	}
+}
+
+func TestPingPackageReportsRetries(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	files := map[string]string{
+		"github.com/example/lib/@v/list":        "v1.0.0\n",
+		"github.com/example/lib/@v/v1.0.0.info": `{"Version":"v1.0.0","Time":"` + recent + `"}`,
+	}
+
+	// The proxy fails the first request for the module list with a rate limit
+	var listRequests int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/github.com/example/lib/@v/list" && atomic.AddInt32(&listRequests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		body, ok := files[r.URL.Path[1:]]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(proxy.Close)
+
+	client := NewClient()
+	client.SetRetryPolicy(fastRetries)
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib", Version: "v1.0.0"}})
+	assert.Len(t, results, 1)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, 1, results[0].Retries)
+	assert.Equal(t, StatusActive, results[0].Status)
+}
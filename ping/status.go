@@ -0,0 +1,61 @@
+package ping
+
+import "time"
+
+// Status is the verdict reached about a dependency
+type Status string
+
+// Statuses a dependency can end up with
+const (
+	StatusArchived   Status = "archived"   // The repository is archived or disabled on its forge
+	StatusDeprecated Status = "deprecated" // The author marked the module deprecated in its latest go.mod
+	StatusNotFound   Status = "not_found"  // No lookup source knows the module
+	StatusStale      Status = "stale"      // Nothing was published within the unmaintained duration
+	StatusUnknown    Status = "unknown"    // The lookups failed, or were skipped (private, not cached, interrupted)
+	StatusActive     Status = "active"     // Published recently enough
+)
+
+// Statuses lists every status from the highest precedence to the lowest.
+// When several apply to a dependency, it is given the first one.
+var Statuses = []Status{
+	StatusArchived,
+	StatusDeprecated,
+	StatusNotFound,
+	StatusStale,
+	StatusUnknown,
+	StatusActive,
+}
+
+// Precedence returns the position of s in Statuses, lower values taking precedence.
+// Unrecognised statuses rank last.
+func (s Status) Precedence() int {
+	for i, status := range Statuses {
+		if status == s {
+			return i
+		}
+	}
+	return len(Statuses)
+}
+
+// Unmaintained reports whether s means the dependency should be replaced
+func (s Status) Unmaintained() bool {
+	return s == StatusArchived || s == StatusNotFound || s == StatusStale
+}
+
+// Acceptance records that the findings about a dependency were acknowledged as an accepted risk
+type Acceptance struct {
+	Pattern string    `json:"pattern"` // Module path or glob the acceptance was given for
+	Reason  string    `json:"reason"`
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"` // Last day the acceptance applies
+	Expired bool      `json:"expired"` // The findings resurface until the acceptance is renewed
+}
+
+// AcceptedRisk reports whether the dependency has findings that are covered by an acceptance
+// still in force. Such dependencies are reported apart and don't count as findings.
+func (s RepoStatus) AcceptedRisk() bool {
+	if s.Accepted == nil || s.Accepted.Expired || s.Incomplete {
+		return false
+	}
+	return s.Status != StatusActive || s.Retracted
+}
@@ -0,0 +1,28 @@
+package ping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusPrecedence(t *testing.T) {
+	assert.Less(t, StatusArchived.Precedence(), StatusDeprecated.Precedence())
+	assert.Less(t, StatusDeprecated.Precedence(), StatusNotFound.Precedence())
+	assert.Less(t, StatusNotFound.Precedence(), StatusStale.Precedence())
+	assert.Less(t, StatusStale.Precedence(), StatusUnknown.Precedence())
+	assert.Less(t, StatusUnknown.Precedence(), StatusActive.Precedence())
+	assert.Equal(t, len(Statuses), Status("bogus").Precedence())
+}
+
+func TestAcceptedRisk(t *testing.T) {
+	acceptance := &Acceptance{Reason: "Feature complete", Owner: "jdoe"}
+	expired := &Acceptance{Reason: "Feature complete", Owner: "jdoe", Expired: true}
+
+	assert.True(t, RepoStatus{Status: StatusStale, Accepted: acceptance}.AcceptedRisk())
+	assert.True(t, RepoStatus{Status: StatusActive, Retracted: true, Accepted: acceptance}.AcceptedRisk())
+	assert.False(t, RepoStatus{Status: StatusActive, Accepted: acceptance}.AcceptedRisk(), "nothing to accept")
+	assert.False(t, RepoStatus{Status: StatusStale, Accepted: expired}.AcceptedRisk(), "expired acceptances resurface")
+	assert.False(t, RepoStatus{Status: StatusUnknown, Incomplete: true, Accepted: acceptance}.AcceptedRisk())
+	assert.False(t, RepoStatus{Status: StatusStale}.AcceptedRisk())
+}
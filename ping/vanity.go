@@ -0,0 +1,234 @@
+package ping
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RepoRoot is the source repository that a module path resolves to
+type RepoRoot struct {
+	Prefix  string // Import path prefix served by the repository
+	VCS     string // Version control system, e.g. "git"
+	RepoURL string // Repository URL from the go-import meta tag
+	Home    string // Project home page from the go-source meta tag, if any
+}
+
+// repoRootCache remembers resolved repository roots, including failed lookups (nil)
+type repoRootCache struct {
+	mu    sync.Mutex
+	roots map[string]*RepoRoot // keyed by module path
+}
+
+// get returns a cached root for modPath, or one whose prefix covers it
+func (rc *repoRootCache) get(modPath string) (root *RepoRoot, ok bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if root, ok := rc.roots[modPath]; ok {
+		return root, true
+	}
+	for _, root := range rc.roots {
+		if root != nil && hasPathPrefix(modPath, root.Prefix) {
+			return root, true
+		}
+	}
+	return nil, false
+}
+
+func (rc *repoRootCache) put(modPath string, root *RepoRoot) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.roots == nil {
+		rc.roots = make(map[string]*RepoRoot)
+	}
+	rc.roots[modPath] = root
+}
+
+// resolveRepoRoot maps a module path to its repository. Paths on github.com and
+// bitbucket.org map directly, gopkg.in paths follow the gopkg.in naming rules and
+// everything else goes through the ?go-get=1 discovery the go command performs,
+// if enabled. It returns nil without an error if the path cannot be resolved.
+func (c *Client) resolveRepoRoot(ctx context.Context, modPath string) (*RepoRoot, error) {
+	if root := staticRepoRoot(modPath); root != nil {
+		return root, nil
+	}
+	if !c.discoverRepos || c.offline {
+		return nil, nil
+	}
+	if root, ok := c.repoRoots.get(modPath); ok {
+		return root, nil
+	}
+
+	root, err := c.discoverRepoRoot(ctx, modPath)
+	c.repoRoots.put(modPath, root)
+	return root, err
+}
+
+// staticRepoRoot resolves module paths whose repository follows from the path alone
+func staticRepoRoot(modPath string) *RepoRoot {
+	parts := strings.Split(modPath, "/")
+	switch parts[0] {
+	case "github.com", "bitbucket.org":
+		if len(parts) < 3 {
+			return nil
+		}
+		prefix := strings.Join(parts[:3], "/")
+		return &RepoRoot{Prefix: prefix, VCS: "git", RepoURL: "https://" + prefix}
+	case "gopkg.in":
+		return gopkgInRepoRoot(parts)
+	}
+	return nil
+}
+
+// gopkgInRepoRoot applies the gopkg.in rules: gopkg.in/pkg.vN is github.com/go-pkg/pkg
+// and gopkg.in/user/pkg.vN is github.com/user/pkg
+func gopkgInRepoRoot(parts []string) *RepoRoot {
+	var user, pkg string
+	switch {
+	case len(parts) >= 2 && strings.Contains(parts[1], ".v"):
+		pkg = parts[1]
+		user = "go-" + pkg[:strings.LastIndex(pkg, ".v")]
+		parts = parts[:2]
+	case len(parts) >= 3 && strings.Contains(parts[2], ".v"):
+		user, pkg = parts[1], parts[2]
+		parts = parts[:3]
+	default:
+		return nil
+	}
+
+	repo := pkg[:strings.LastIndex(pkg, ".v")]
+	if repo == "" || user == "go-" {
+		return nil
+	}
+	return &RepoRoot{
+		Prefix:  strings.Join(parts, "/"),
+		VCS:     "git",
+		RepoURL: fmt.Sprintf("https://github.com/%s/%s", user, repo),
+	}
+}
+
+// discoverRepoRoot fetches https://<modPath>?go-get=1 and reads its go-import and go-source meta tags
+func (c *Client) discoverRepoRoot(ctx context.Context, modPath string) (*RepoRoot, error) {
+	host, _, _ := strings.Cut(modPath, "/")
+	_, body, err := c.cachedGet(ctx, host, modPath, "go-get", "https://"+modPath+"?go-get=1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the go command, the meta tags are trusted even on error pages
+	imports, sources, err := parseMetaGoImports(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parsing go-import meta tags for %s: %v", modPath, err)
+	}
+
+	var root *RepoRoot
+	for _, imp := range imports {
+		if !hasPathPrefix(modPath, imp.Prefix) || imp.VCS == "mod" {
+			continue
+		}
+		if root != nil {
+			return nil, fmt.Errorf("multiple go-import meta tags match %s", modPath)
+		}
+		root = imp
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no go-import meta tag for %s", modPath)
+	}
+
+	for _, src := range sources {
+		if src.Prefix == root.Prefix {
+			root.Home = src.Home
+		}
+	}
+
+	return root, nil
+}
+
+// parseMetaGoImports reads the go-import and go-source meta tags from the head of an HTML page
+func parseMetaGoImports(r io.Reader) (imports, sources []*RepoRoot, err error) {
+	d := xml.NewDecoder(r)
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "ascii") {
+			return input, nil
+		}
+		return nil, fmt.Errorf("can't decode XML document using charset %q", charset)
+	}
+	d.Strict = false
+
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			if err == io.EOF || len(imports) > 0 {
+				err = nil
+			}
+			return imports, sources, err
+		}
+		if e, ok := t.(xml.StartElement); ok && strings.EqualFold(e.Name.Local, "body") {
+			return imports, sources, nil
+		}
+		if e, ok := t.(xml.EndElement); ok && strings.EqualFold(e.Name.Local, "head") {
+			return imports, sources, nil
+		}
+		e, ok := t.(xml.StartElement)
+		if !ok || !strings.EqualFold(e.Name.Local, "meta") {
+			continue
+		}
+
+		fields := strings.Fields(attrValue(e.Attr, "content"))
+		switch attrValue(e.Attr, "name") {
+		case "go-import":
+			if len(fields) == 3 {
+				imports = append(imports, &RepoRoot{Prefix: fields[0], VCS: fields[1], RepoURL: fields[2]})
+			}
+		case "go-source":
+			if len(fields) >= 2 {
+				sources = append(sources, &RepoRoot{Prefix: fields[0], Home: fields[1]})
+			}
+		}
+	}
+}
+
+// attrValue returns the value of the named attribute, ignoring case
+func attrValue(attrs []xml.Attr, name string) string {
+	for _, a := range attrs {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// hasPathPrefix reports whether the slash-separated path s begins with the elements of prefix
+func hasPathPrefix(s, prefix string) bool {
+	return s == prefix || (strings.HasPrefix(s, prefix) && len(s) > len(prefix) && s[len(prefix)] == '/')
+}
+
+// forgeProject maps a repository URL such as https://github.com/uber-go/zap.git
+// to the host and project path used by forge checks
+func forgeProject(repoURL string) (host, project string, ok bool) {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	project = strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if !strings.Contains(project, "/") {
+		return "", "", false
+	}
+	return u.Hostname(), project, true
+}
+
+// webURL turns a repository URL into something a person can open in a browser
+func webURL(root *RepoRoot) string {
+	if strings.HasPrefix(root.RepoURL, "https://") || strings.HasPrefix(root.RepoURL, "http://") {
+		return strings.TrimSuffix(root.RepoURL, ".git")
+	}
+	if root.Home != "" && root.Home != "_" {
+		return root.Home
+	}
+	return root.RepoURL
+}
@@ -0,0 +1,203 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestVanityServer starts a server answering ?go-get=1 requests with the given HTML,
+// keyed by host and path (e.g. "go.uber.org/zap"), and returns the number of requests made
+func newTestVanityServer(t *testing.T, pages map[string]string) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("go-get") != "1" {
+			http.Error(w, "missing go-get", http.StatusBadRequest)
+			return
+		}
+		page, ok := pages[r.Host+r.URL.Path]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// redirectTransport sends every request to server, keeping the original Host header
+func redirectTransport(server *httptest.Server) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Host = req.URL.Host
+		req.URL.Scheme = "http"
+		req.URL.Host = server.Listener.Addr().String()
+		return http.DefaultTransport.RoundTrip(req)
+	})
+}
+
+func TestParseMetaGoImports(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap">
+<meta name="go-source" content="go.uber.org/zap https://github.com/uber-go/zap https://github.com/uber-go/zap/tree/master{/dir} https://github.com/uber-go/zap/tree/master{/dir}/{file}#L{line}">
+<META NAME="go-import" CONTENT="go.uber.org/zap mod https://proxy.example.com">
+</head>
+<body><meta name="go-import" content="ignored git https://example.com/ignored"></body>
+</html>`
+
+	imports, sources, err := parseMetaGoImports(strings.NewReader(page))
+	assert.NoError(t, err)
+	assert.Equal(t, []*RepoRoot{
+		{Prefix: "go.uber.org/zap", VCS: "git", RepoURL: "https://github.com/uber-go/zap"},
+		{Prefix: "go.uber.org/zap", VCS: "mod", RepoURL: "https://proxy.example.com"},
+	}, imports)
+	assert.Equal(t, []*RepoRoot{{Prefix: "go.uber.org/zap", Home: "https://github.com/uber-go/zap"}}, sources)
+}
+
+func TestStaticRepoRoot(t *testing.T) {
+	tests := []struct {
+		modPath string
+		prefix  string
+		repoURL string
+	}{
+		{modPath: "github.com/go-chi/chi/v5", prefix: "github.com/go-chi/chi", repoURL: "https://github.com/go-chi/chi"},
+		{modPath: "bitbucket.org/team/repo/sub", prefix: "bitbucket.org/team/repo", repoURL: "https://bitbucket.org/team/repo"},
+		{modPath: "gopkg.in/yaml.v3", prefix: "gopkg.in/yaml.v3", repoURL: "https://github.com/go-yaml/yaml"},
+		{modPath: "gopkg.in/check.v1", prefix: "gopkg.in/check.v1", repoURL: "https://github.com/go-check/check"},
+		{modPath: "gopkg.in/fsnotify/fsnotify.v1", prefix: "gopkg.in/fsnotify/fsnotify.v1", repoURL: "https://github.com/fsnotify/fsnotify"},
+		{modPath: "gopkg.in/nothing", prefix: ""},
+		{modPath: "github.com/pkg", prefix: ""},
+		{modPath: "go.uber.org/zap", prefix: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modPath, func(t *testing.T) {
+			root := staticRepoRoot(tt.modPath)
+			if tt.prefix == "" {
+				assert.Nil(t, root)
+				return
+			}
+			assert.Equal(t, tt.prefix, root.Prefix)
+			assert.Equal(t, tt.repoURL, root.RepoURL)
+		})
+	}
+}
+
+func TestResolveRepoRoot(t *testing.T) {
+	server, requests := newTestVanityServer(t, map[string]string{
+		"k8s.io/client-go": `<meta name="go-import" content="k8s.io/client-go git https://github.com/kubernetes/client-go">`,
+		"go.example.com/tools/lint": `<meta name="go-import" content="go.example.com/tools git ssh://git@git.example.com/tools.git">
+<meta name="go-source" content="go.example.com/tools https://git.example.com/tools _ _">`,
+		"go.example.com/ambiguous": `<meta name="go-import" content="go.example.com/ambiguous git https://a.example.com/x">
+<meta name="go-import" content="go.example.com git https://b.example.com/y">`,
+	})
+
+	client := NewClient()
+	client.httpClient.Transport = redirectTransport(server)
+
+	t.Run("Disabled discovery", func(t *testing.T) {
+		root, err := client.resolveRepoRoot(context.Background(), "k8s.io/client-go")
+		assert.NoError(t, err)
+		assert.Nil(t, root)
+		assert.Equal(t, int32(0), atomic.LoadInt32(requests))
+	})
+
+	client.SetDiscoverRepos(true)
+
+	t.Run("Vanity path", func(t *testing.T) {
+		root, err := client.resolveRepoRoot(context.Background(), "k8s.io/client-go")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://github.com/kubernetes/client-go", root.RepoURL)
+
+		// The second lookup is served from the cache
+		before := atomic.LoadInt32(requests)
+		_, err = client.resolveRepoRoot(context.Background(), "k8s.io/client-go")
+		assert.NoError(t, err)
+		assert.Equal(t, before, atomic.LoadInt32(requests))
+	})
+
+	t.Run("Source home for non-web repository URL", func(t *testing.T) {
+		root, err := client.resolveRepoRoot(context.Background(), "go.example.com/tools/lint")
+		assert.NoError(t, err)
+		assert.Equal(t, "go.example.com/tools", root.Prefix)
+		assert.Equal(t, "https://git.example.com/tools", webURL(root))
+
+		// Other modules under the same prefix reuse the cached root
+		before := atomic.LoadInt32(requests)
+		root, err = client.resolveRepoRoot(context.Background(), "go.example.com/tools/vet")
+		assert.NoError(t, err)
+		assert.Equal(t, "go.example.com/tools", root.Prefix)
+		assert.Equal(t, before, atomic.LoadInt32(requests))
+	})
+
+	t.Run("Multiple matching tags", func(t *testing.T) {
+		_, err := client.resolveRepoRoot(context.Background(), "go.example.com/ambiguous")
+		assert.ErrorContains(t, err, "multiple go-import meta tags")
+	})
+
+	t.Run("No meta tags", func(t *testing.T) {
+		_, err := client.resolveRepoRoot(context.Background(), "go.example.com/missing")
+		assert.ErrorContains(t, err, "no go-import meta tag")
+	})
+}
+
+func TestForgeProject(t *testing.T) {
+	host, project, ok := forgeProject("https://gitlab.com/group/sub/project.git")
+	assert.True(t, ok)
+	assert.Equal(t, "gitlab.com", host)
+	assert.Equal(t, "group/sub/project", project)
+
+	_, _, ok = forgeProject("https://go.googlesource.com/tools")
+	assert.False(t, ok)
+}
+
+func TestPingPackageWithVanityPath(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"go.uber.org/zap/@v/list":         "v1.27.0\n",
+		"go.uber.org/zap/@v/v1.27.0.info": `{"Version":"v1.27.0","Time":"` + recent + `"}`,
+	})
+	vanity, _ := newTestVanityServer(t, map[string]string{
+		"go.uber.org/zap": `<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap">`,
+	})
+	api := newTestForgeAPI(t, map[string]string{
+		"/repos/uber-go/zap": `{"full_name":"uber-go/zap","html_url":"https://github.com/uber-go/zap","archived":true}`,
+	}, nil)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetDiscoverRepos(true)
+	forge, err := NewForge("github", "github.com", api.URL, "")
+	assert.NoError(t, err)
+	client.AddForge(forge)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	// Only go-get discovery goes to the vanity server, the stubs are reached directly
+	client.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("go-get") == "1" {
+			return redirectTransport(vanity).RoundTrip(req)
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "go.uber.org/zap"}})
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "uber-go", results[0].Owner)
+	assert.Equal(t, "zap", results[0].Repo)
+	assert.Equal(t, "https://github.com/uber-go/zap", results[0].RepoURL)
+	assert.True(t, results[0].RepoArchived)
+	assert.Equal(t, StatusArchived, results[0].Status)
+}
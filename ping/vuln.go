@@ -0,0 +1,244 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/semver"
+)
+
+// DefaultVulnDB is the Go vulnerability database
+const DefaultVulnDB = "https://vuln.go.dev"
+
+// vulnDB looks up known vulnerabilities in a database following the Go vulnerability
+// database protocol, whose module index is downloaded once and shared by all checks
+type vulnDB struct {
+	url string
+
+	mu       sync.Mutex
+	index    map[string][]vulnEntry // Vulnerabilities keyed by module path, nil until downloaded
+	entries  map[string]*osvEntry   // Downloaded entries keyed by ID
+	inflight map[string]*vulnFetch  // Downloads in progress, keyed by resource
+}
+
+// vulnFetch is a download in progress, whose result is shared by every check waiting for it
+type vulnFetch struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// vulnEntry is a vulnerability listed in the index/modules.json file of a vulnerability database
+type vulnEntry struct {
+	ID    string `json:"id"`
+	Fixed string `json:"fixed"` // Latest version the vulnerability is fixed in, empty if there is no fix
+}
+
+// osvEntry is the part of a vulnerability's OSV entry (ID/<id>.json) needed to tell
+// which versions of which modules it affects
+type osvEntry struct {
+	Affected []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		Ranges []osvRange `json:"ranges"`
+	} `json:"affected"`
+}
+
+// osvRange lists the versions a vulnerability was introduced and fixed in,
+// without the "v" prefix; an introduced version of "0" means all earlier versions
+type osvRange struct {
+	Type   string `json:"type"`
+	Events []struct {
+		Introduced string `json:"introduced"`
+		Fixed      string `json:"fixed"`
+	} `json:"events"`
+}
+
+// SetVulnDB enables looking up the known vulnerabilities of required versions in the
+// vulnerability database served at url, e.g. DefaultVulnDB. An empty url turns it off.
+func (c *Client) SetVulnDB(url string) {
+	if url == "" {
+		c.vulns = nil
+		return
+	}
+	c.vulns = &vulnDB{url: strings.TrimSuffix(url, "/")}
+}
+
+// vulnerabilities returns the IDs of the known vulnerabilities that affect version of modPath.
+// The index only tells the latest fixed version, so the entry of every vulnerability that
+// may affect version is downloaded to check it against the affected ranges.
+func (c *Client) vulnerabilities(ctx context.Context, modPath, version string) ([]string, error) {
+	index, err := c.vulnIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, vuln := range index[modPath] {
+		if vuln.Fixed != "" && semver.Compare(version, osvVersion(vuln.Fixed)) >= 0 {
+			continue
+		}
+		entry, err := c.vulnEntry(ctx, vuln.ID)
+		if err != nil {
+			return nil, err
+		}
+		if entry.affects(modPath, version) {
+			ids = append(ids, vuln.ID)
+		}
+	}
+	return ids, nil
+}
+
+// affects reports whether version of modPath falls in one of the entry's SEMVER ranges
+func (e *osvEntry) affects(modPath, version string) bool {
+	for _, affected := range e.Affected {
+		if affected.Package.Name != modPath {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			if r.Type == "SEMVER" && r.contains(version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contains reports whether version was introduced and not yet fixed by the range's events,
+// which are sorted by version
+func (r osvRange) contains(version string) bool {
+	affected := false
+	for _, event := range r.Events {
+		switch {
+		case event.Introduced == "0":
+			affected = true
+		case event.Introduced != "" && semver.Compare(version, osvVersion(event.Introduced)) >= 0:
+			affected = true
+		case event.Fixed != "" && semver.Compare(version, osvVersion(event.Fixed)) >= 0:
+			affected = false
+		}
+	}
+	return affected
+}
+
+// osvVersion turns a version of an OSV entry into a semantic version with the "v" prefix
+func osvVersion(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+// vulnEntry downloads the OSV entry of a vulnerability, once per run
+func (c *Client) vulnEntry(ctx context.Context, id string) (*osvEntry, error) {
+	c.vulns.mu.Lock()
+	entry, ok := c.vulns.entries[id]
+	c.vulns.mu.Unlock()
+	if ok {
+		return entry, nil
+	}
+
+	value, err := c.vulns.fetchOnce(ctx, "ID/"+id, func() (any, error) {
+		target := c.vulns.url + "/ID/" + id + ".json"
+		statusCode, body, err := c.cachedGet(ctx, proxyHost(c.vulns.url), "ID", id, target, nil)
+		if err != nil {
+			return nil, err
+		}
+		if statusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s returned status %d", target, statusCode)
+		}
+
+		entry := &osvEntry{}
+		if err := json.Unmarshal(body, entry); err != nil {
+			return nil, fmt.Errorf("invalid vulnerability entry %s: %v", target, err)
+		}
+		c.vulns.mu.Lock()
+		defer c.vulns.mu.Unlock()
+		if c.vulns.entries == nil {
+			c.vulns.entries = make(map[string]*osvEntry)
+		}
+		c.vulns.entries[id] = entry
+		return entry, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*osvEntry), nil
+}
+
+// vulnIndex downloads the module index of the vulnerability database on first use.
+// Failed downloads are retried by the next check.
+func (c *Client) vulnIndex(ctx context.Context) (map[string][]vulnEntry, error) {
+	c.vulns.mu.Lock()
+	index := c.vulns.index
+	c.vulns.mu.Unlock()
+	if index != nil {
+		return index, nil
+	}
+
+	value, err := c.vulns.fetchOnce(ctx, "index", func() (any, error) {
+		target := c.vulns.url + "/index/modules.json"
+		statusCode, body, err := c.cachedGet(ctx, proxyHost(c.vulns.url), "index", "modules", target, nil)
+		if err != nil {
+			return nil, err
+		}
+		if statusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s returned status %d", target, statusCode)
+		}
+
+		var modules []struct {
+			Path  string      `json:"path"`
+			Vulns []vulnEntry `json:"vulns"`
+		}
+		if err := json.Unmarshal(body, &modules); err != nil {
+			return nil, fmt.Errorf("invalid vulnerability index %s: %v", target, err)
+		}
+
+		index := make(map[string][]vulnEntry, len(modules))
+		for _, module := range modules {
+			index[module.Path] = module.Vulns
+		}
+		c.vulns.mu.Lock()
+		defer c.vulns.mu.Unlock()
+		c.vulns.index = index
+		return index, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(map[string][]vulnEntry), nil
+}
+
+// fetchOnce runs fetch for key, or waits for the result of the call already running for it,
+// so that concurrent checks share downloads without holding the lock while they're made
+func (db *vulnDB) fetchOnce(ctx context.Context, key string, fetch func() (any, error)) (any, error) {
+	db.mu.Lock()
+	if call, ok := db.inflight[key]; ok {
+		db.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &vulnFetch{done: make(chan struct{})}
+	if db.inflight == nil {
+		db.inflight = make(map[string]*vulnFetch)
+	}
+	db.inflight[key] = call
+	db.mu.Unlock()
+
+	call.value, call.err = fetch()
+
+	db.mu.Lock()
+	delete(db.inflight, key)
+	db.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
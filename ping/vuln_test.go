@@ -0,0 +1,117 @@
+package ping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingPackageVulnerabilities(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/@v/list":        "v1.0.0\nv1.3.0\n",
+		"github.com/example/lib/@v/v1.3.0.info": `{"Version":"v1.3.0","Time":"` + recent + `"}`,
+		"github.com/example/lib/@v/v1.3.0.mod":  "module github.com/example/lib\n",
+	})
+
+	entries := map[string]string{
+		// Fixed on the 1.1 branch; the index only lists the latest fix
+		"GO-2023-0002": `{"id": "GO-2023-0002", "affected": [{"package": {"name": "github.com/example/lib"}, "ranges": [
+			{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.1.5"}, {"introduced": "1.2.0"}, {"fixed": "1.3.0"}]}]}]}`,
+		"GO-2023-0003": `{"id": "GO-2023-0003", "affected": [{"package": {"name": "github.com/example/lib"}, "ranges": [
+			{"type": "SEMVER", "events": [{"introduced": "1.2.5"}]}]}]}`,
+		"GO-2023-0005": `{"id": "GO-2023-0005", "affected": [{"package": {"name": "github.com/example/lib"}, "ranges": [
+			{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.1.0"}, {"introduced": "1.3.0"}, {"fixed": "1.4.0"}]}]}]}`,
+	}
+
+	var indexRequests, entryRequests int32
+	db := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := strings.CutPrefix(r.URL.Path, "/ID/"); ok {
+			entry, ok := entries[strings.TrimSuffix(id, ".json")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			atomic.AddInt32(&entryRequests, 1)
+			w.Write([]byte(entry))
+			return
+		}
+		if r.URL.Path != "/index/modules.json" {
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&indexRequests, 1)
+		w.Write([]byte(`[
+			{"path": "github.com/example/lib", "vulns": [
+				{"id": "GO-2023-0001", "modified": "2023-01-01T00:00:00Z", "fixed": "1.1.0"},
+				{"id": "GO-2023-0002", "modified": "2023-01-01T00:00:00Z", "fixed": "1.3.0"},
+				{"id": "GO-2023-0003", "modified": "2023-01-01T00:00:00Z"},
+				{"id": "GO-2023-0005", "modified": "2023-01-01T00:00:00Z", "fixed": "1.4.0"}
+			]},
+			{"path": "github.com/other/lib", "vulns": [{"id": "GO-2023-0004", "modified": "2023-01-01T00:00:00Z"}]}
+		]`))
+	}))
+	t.Cleanup(db.Close)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetVulnDB(db.URL)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/lib", Version: "v1.2.0"},
+	})
+	assert.Len(t, results, 1)
+	assert.Equal(t, []string{"GO-2023-0002"}, results[0].Vulnerabilities)
+	assert.Equal(t, 50, results[0].Health.Signal(HealthVulnerabilities).Score)
+
+	var evidence []Evidence
+	for _, e := range results[0].Evidence {
+		if e.Signal == SignalVulnerability {
+			evidence = append(evidence, e)
+		}
+	}
+	assert.Len(t, evidence, 1)
+	assert.Equal(t, "https://pkg.go.dev/vuln/GO-2023-0002", evidence[0].URL)
+
+	// Versions on a patched branch are not affected
+	results = client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/lib", Version: "v1.1.6"},
+	})
+	assert.Empty(t, results[0].Vulnerabilities)
+
+	// The index and entries are downloaded once and shared by later checks
+	results = client.PingPackage(context.Background(), []parser.Dependency{
+		{Path: "github.com/example/lib", Version: "v1.3.0"},
+	})
+	assert.Equal(t, []string{"GO-2023-0003", "GO-2023-0005"}, results[0].Vulnerabilities)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&indexRequests))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&entryRequests))
+}
+
+func TestPingPackageVulnerabilitiesUnavailable(t *testing.T) {
+	recent := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	proxy := newTestProxy(t, map[string]string{
+		"github.com/example/lib/@v/list":        "v1.0.0\n",
+		"github.com/example/lib/@v/v1.0.0.info": `{"Version":"v1.0.0","Time":"` + recent + `"}`,
+	})
+	db := newFailingProxy(t, http.StatusNotFound)
+
+	client := NewClient()
+	assert.NoError(t, client.SetGoProxy(proxy.URL))
+	client.SetVulnDB(db.URL)
+	client.SetProgressCallback(func(dependency string, status string) {})
+
+	results := client.PingPackage(context.Background(), []parser.Dependency{{Path: "github.com/example/lib", Version: "v1.0.0"}})
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusActive, results[0].Status)
+	assert.Empty(t, results[0].Vulnerabilities)
+	assert.Nil(t, results[0].Health.Signal(HealthVulnerabilities))
+}
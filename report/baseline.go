@@ -0,0 +1,159 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+// baselineScoreDrop is how many points a health score must lose, compared to the baseline,
+// for the dependency to count as worse. Scores drift down a little as time passes.
+const baselineScoreDrop = 10
+
+// Baseline is a snapshot of the findings of a run, so that later runs only report new ones
+type Baseline struct {
+	Created  time.Time       `json:"created"`
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry is the finding about a single dependency at the time the baseline was written
+type BaselineEntry struct {
+	Module    string      `json:"module"`
+	Version   string      `json:"version,omitempty"`
+	Status    ping.Status `json:"status"`
+	Retracted bool        `json:"retracted,omitempty"`
+	Score     *int        `json:"score,omitempty"` // Health score, nil if there was none
+}
+
+// BaselineDiff is how the results of a run compare to a baseline
+type BaselineDiff struct {
+	Worse map[string]string // How the finding got worse, keyed by module path
+	Stale map[string]string // Why the entry is no longer needed, keyed by module path
+}
+
+// hasFinding reports whether a dependency has something worth acting on: an unmaintained,
+// deprecated or retracted module, or anything violating the policy
+func hasFinding(repo ping.RepoStatus, violations map[string]string) bool {
+	if repo.Incomplete || repo.Private || repo.NotCached {
+		return false
+	}
+	return repo.Status.Unmaintained() || repo.Status == ping.StatusDeprecated || repo.Retracted ||
+		violations[repo.ModulePath] != ""
+}
+
+// NewBaseline snapshots the findings in repoStatus. Accepted risks are left out, they have
+// an expiry date of their own.
+func NewBaseline(repoStatus []ping.RepoStatus, policy *Policy) *Baseline {
+	violations := policy.Violations(repoStatus)
+	baseline := &Baseline{Created: time.Now().UTC(), Findings: []BaselineEntry{}}
+	for _, repo := range repoStatus {
+		if !hasFinding(repo, violations) || repo.AcceptedRisk() {
+			continue
+		}
+		entry := BaselineEntry{Module: repo.ModulePath, Version: repo.Version, Status: repo.Status, Retracted: repo.Retracted}
+		if repo.Health != nil {
+			score := repo.Health.Score
+			entry.Score = &score
+		}
+		baseline.Findings = append(baseline.Findings, entry)
+	}
+	sort.Slice(baseline.Findings, func(i, j int) bool { return baseline.Findings[i].Module < baseline.Findings[j].Module })
+	return baseline
+}
+
+// LoadBaseline reads a baseline file written by Write
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline file %s: %v", path, err)
+	}
+	return baseline, nil
+}
+
+// Write saves the baseline to path
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Apply marks the dependencies whose findings are in the baseline as Baselined, unless
+// they got worse: a status of higher precedence, a newly retracted version or a health
+// score lower by baselineScoreDrop or more. It also returns the entries that are no
+// longer needed, so that the baseline can be pruned.
+func (b *Baseline) Apply(repoStatus []ping.RepoStatus, policy *Policy) BaselineDiff {
+	diff := BaselineDiff{Worse: make(map[string]string), Stale: make(map[string]string)}
+	violations := policy.Violations(repoStatus)
+
+	entries := make(map[string]BaselineEntry, len(b.Findings))
+	for _, entry := range b.Findings {
+		entries[entry.Module] = entry
+	}
+	checked := make(map[string]bool, len(repoStatus))
+	for i, repo := range repoStatus {
+		checked[repo.ModulePath] = true
+		entry, ok := entries[repo.ModulePath]
+		if !ok {
+			continue
+		}
+		if !hasFinding(repo, violations) {
+			// A dependency that couldn't be checked may still have its finding
+			if repo.Status != ping.StatusUnknown {
+				diff.Stale[repo.ModulePath] = fmt.Sprintf("now %s", repo.Status)
+			}
+			continue
+		}
+
+		switch {
+		case repo.Status.Precedence() < entry.Status.Precedence():
+			diff.Worse[repo.ModulePath] = fmt.Sprintf("%s, was %s", repo.Status, entry.Status)
+		case repo.Retracted && !entry.Retracted:
+			diff.Worse[repo.ModulePath] = fmt.Sprintf("%s now retracted", repo.Version)
+		case entry.Score != nil && repo.Health != nil && repo.Health.Score <= *entry.Score-baselineScoreDrop:
+			diff.Worse[repo.ModulePath] = fmt.Sprintf("score %d, was %d", repo.Health.Score, *entry.Score)
+		default:
+			repoStatus[i].Baselined = true
+		}
+	}
+	for _, entry := range b.Findings {
+		if !checked[entry.Module] {
+			diff.Stale[entry.Module] = "no longer checked"
+		}
+	}
+	return diff
+}
+
+// OutputBaselineDiff prints the findings that got worse than the baseline and the entries
+// that can be pruned to stderr, so that it doesn't get mixed with JSON output
+func OutputBaselineDiff(diff BaselineDiff, path string) {
+	if len(diff.Worse) > 0 {
+		fmt.Fprintf(os.Stderr, "\nWorse Than The Baseline:\n")
+		printSorted(diff.Worse)
+	}
+	if len(diff.Stale) > 0 {
+		fmt.Fprintf(os.Stderr, "\nStale Baseline Entries (Remove From %s):\n", path)
+		printSorted(diff.Stale)
+	}
+}
+
+// printSorted prints module paths and their reason to stderr, ordered by module path
+func printSorted(reasons map[string]string) {
+	paths := make([]string, 0, len(reasons))
+	for modPath := range reasons {
+		paths = append(paths, modPath)
+	}
+	sort.Strings(paths)
+	for _, modPath := range paths {
+		fmt.Fprintf(os.Stderr, "%s (%s)\n", modPath, reasons[modPath])
+	}
+}
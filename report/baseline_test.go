@@ -0,0 +1,96 @@
+package report
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+func TestBaselineRoundTrip(t *testing.T) {
+	policy, _ := ParsePolicy([]string{"score<50"})
+	baseline := NewBaseline(setupPolicyResults(), policy)
+
+	var modules []string
+	for _, entry := range baseline.Findings {
+		modules = append(modules, entry.Module)
+	}
+	expected := []string{"github.com/archived/repo", "github.com/deprecated/repo", "github.com/retracted/repo", "github.com/stale/repo"}
+	if !reflect.DeepEqual(modules, expected) {
+		t.Errorf("Expected findings for %v, got %v", expected, modules)
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := baseline.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Findings, baseline.Findings) || !loaded.Created.Equal(baseline.Created) {
+		t.Errorf("Expected %+v after a round trip, got %+v", baseline, loaded)
+	}
+}
+
+func TestBaselineApply(t *testing.T) {
+	score := func(s int) *int { return &s }
+	baseline := &Baseline{Findings: []BaselineEntry{
+		{Module: "github.com/same/repo", Status: ping.StatusStale, Score: score(40)},
+		{Module: "github.com/archived/repo", Status: ping.StatusStale},
+		{Module: "github.com/retracted/repo", Status: ping.StatusStale},
+		{Module: "github.com/score/repo", Status: ping.StatusActive, Score: score(45)},
+		{Module: "github.com/recovered/repo", Status: ping.StatusStale},
+		{Module: "github.com/broken/repo", Status: ping.StatusStale},
+		{Module: "github.com/removed/repo", Status: ping.StatusArchived},
+	}}
+	results := []ping.RepoStatus{
+		{ModulePath: "github.com/same/repo", Status: ping.StatusStale, Health: &ping.Health{Score: 35}},
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived},
+		{ModulePath: "github.com/retracted/repo", Status: ping.StatusStale, Version: "v1.2.0", Retracted: true},
+		{ModulePath: "github.com/score/repo", Status: ping.StatusActive, Health: &ping.Health{Score: 30}},
+		{ModulePath: "github.com/recovered/repo", Status: ping.StatusActive},
+		{ModulePath: "github.com/broken/repo", Status: ping.StatusUnknown, Error: "connection refused"},
+		{ModulePath: "github.com/new/repo", Status: ping.StatusDeprecated},
+	}
+	policy, _ := ParsePolicy([]string{"stale,archived,deprecated,score<50"})
+
+	diff := baseline.Apply(results, policy)
+
+	expectedWorse := map[string]string{
+		"github.com/archived/repo":  "archived, was stale",
+		"github.com/retracted/repo": "v1.2.0 now retracted",
+		"github.com/score/repo":     "score 30, was 45",
+	}
+	if !reflect.DeepEqual(diff.Worse, expectedWorse) {
+		t.Errorf("Worse = %v, want %v", diff.Worse, expectedWorse)
+	}
+	expectedStale := map[string]string{
+		"github.com/recovered/repo": "now active",
+		"github.com/removed/repo":   "no longer checked",
+	}
+	if !reflect.DeepEqual(diff.Stale, expectedStale) {
+		t.Errorf("Stale = %v, want %v", diff.Stale, expectedStale)
+	}
+
+	var baselined []string
+	for _, repo := range results {
+		if repo.Baselined {
+			baselined = append(baselined, repo.ModulePath)
+		}
+	}
+	if !reflect.DeepEqual(baselined, []string{"github.com/same/repo"}) {
+		t.Errorf("Expected only github.com/same/repo to be baselined, got %v", baselined)
+	}
+
+	expectedViolations := map[string]string{
+		"github.com/archived/repo":  "archived",
+		"github.com/retracted/repo": "stale",
+		"github.com/score/repo":     "score 30",
+		"github.com/new/repo":       "deprecated",
+	}
+	if got := policy.Violations(results); !reflect.DeepEqual(got, expectedViolations) {
+		t.Errorf("Violations() = %v, want %v", got, expectedViolations)
+	}
+}
@@ -0,0 +1,226 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+	"github.com/Bhupesh-V/godeping/utils"
+)
+
+// SavedReport is the part of a report written by OutputJSON that runs are compared on
+type SavedReport struct {
+	Module       string            `json:"module"`
+	Dependencies []ping.RepoStatus `json:"dependencies"`
+}
+
+// LoadReport reads a report written by OutputJSON
+func LoadReport(path string) (*SavedReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid report %s: %v", path, err)
+	}
+	if _, ok := fields["dependencies"]; !ok {
+		return nil, fmt.Errorf("invalid report %s: no dependencies, expected the output of godeping -json", path)
+	}
+	saved := &SavedReport{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return nil, fmt.Errorf("invalid report %s: %v", path, err)
+	}
+	return saved, nil
+}
+
+// DependencyChange is how a dependency differs between two reports. Fields about the side
+// the dependency is missing from are left empty.
+type DependencyChange struct {
+	Module     string      `json:"module"`
+	OldStatus  ping.Status `json:"oldStatus,omitempty"`
+	NewStatus  ping.Status `json:"newStatus,omitempty"`
+	OldVersion string      `json:"oldVersion,omitempty"`
+	NewVersion string      `json:"newVersion,omitempty"`
+	OldScore   *int        `json:"oldScore,omitempty"`
+	NewScore   *int        `json:"newScore,omitempty"`
+}
+
+// ReportDiff lists what changed between two reports, each list ordered by module path
+// except for ScoreChanges, which lists the largest drops first
+type ReportDiff struct {
+	OldModule         string             `json:"oldModule"`
+	NewModule         string             `json:"newModule"`
+	NewlyUnmaintained []DependencyChange `json:"newlyUnmaintained"` // Became archived, not found or stale
+	Recovered         []DependencyChange `json:"recovered"`         // Were unmaintained or deprecated, now active
+	StatusChanges     []DependencyChange `json:"statusChanges"`     // Any other status transition
+	Added             []DependencyChange `json:"added"`
+	Removed           []DependencyChange `json:"removed"`
+	ScoreChanges      []DependencyChange `json:"scoreChanges"`
+}
+
+// DiffReports compares the dependencies of two reports
+func DiffReports(old, new *SavedReport) *ReportDiff {
+	diff := &ReportDiff{
+		OldModule:         old.Module,
+		NewModule:         new.Module,
+		NewlyUnmaintained: []DependencyChange{},
+		Recovered:         []DependencyChange{},
+		StatusChanges:     []DependencyChange{},
+		Added:             []DependencyChange{},
+		Removed:           []DependencyChange{},
+		ScoreChanges:      []DependencyChange{},
+	}
+
+	oldDeps := make(map[string]ping.RepoStatus, len(old.Dependencies))
+	for _, repo := range old.Dependencies {
+		oldDeps[repo.ModulePath] = repo
+	}
+	newDeps := make(map[string]bool, len(new.Dependencies))
+	for _, repo := range new.Dependencies {
+		newDeps[repo.ModulePath] = true
+		before, ok := oldDeps[repo.ModulePath]
+		if !ok {
+			diff.Added = append(diff.Added, dependencyChange(nil, &repo))
+			continue
+		}
+
+		change := dependencyChange(&before, &repo)
+		switch {
+		case before.Status == repo.Status:
+		case repo.Status.Unmaintained() && !before.Status.Unmaintained():
+			diff.NewlyUnmaintained = append(diff.NewlyUnmaintained, change)
+		case repo.Status == ping.StatusActive && (before.Status.Unmaintained() || before.Status == ping.StatusDeprecated):
+			diff.Recovered = append(diff.Recovered, change)
+		default:
+			diff.StatusChanges = append(diff.StatusChanges, change)
+		}
+		if change.OldScore != nil && change.NewScore != nil && *change.OldScore != *change.NewScore {
+			diff.ScoreChanges = append(diff.ScoreChanges, change)
+		}
+	}
+	for _, repo := range old.Dependencies {
+		if !newDeps[repo.ModulePath] {
+			diff.Removed = append(diff.Removed, dependencyChange(&repo, nil))
+		}
+	}
+
+	for _, changes := range [][]DependencyChange{diff.NewlyUnmaintained, diff.Recovered, diff.StatusChanges, diff.Added, diff.Removed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Module < changes[j].Module })
+	}
+	sort.Slice(diff.ScoreChanges, func(i, j int) bool {
+		a, b := diff.ScoreChanges[i], diff.ScoreChanges[j]
+		if deltaA, deltaB := *a.NewScore-*a.OldScore, *b.NewScore-*b.OldScore; deltaA != deltaB {
+			return deltaA < deltaB
+		}
+		return a.Module < b.Module
+	})
+	return diff
+}
+
+// dependencyChange describes a dependency in the old and new report, either of which may be nil
+func dependencyChange(old, new *ping.RepoStatus) DependencyChange {
+	score := func(repo *ping.RepoStatus) *int {
+		if repo.Health == nil {
+			return nil
+		}
+		score := repo.Health.Score
+		return &score
+	}
+
+	var change DependencyChange
+	if old != nil {
+		change.Module = old.ModulePath
+		change.OldStatus, change.OldVersion, change.OldScore = old.Status, old.Version, score(old)
+	}
+	if new != nil {
+		change.Module = new.ModulePath
+		change.NewStatus, change.NewVersion, change.NewScore = new.Status, new.Version, score(new)
+	}
+	return change
+}
+
+// Empty reports whether nothing changed between the two reports
+func (d *ReportDiff) Empty() bool {
+	return len(d.NewlyUnmaintained)+len(d.Recovered)+len(d.StatusChanges)+len(d.Added)+len(d.Removed)+len(d.ScoreChanges) == 0
+}
+
+// OutputDiffJSON prints the differences between two reports in JSON format
+func OutputDiffJSON(diff *ReportDiff) {
+	jsonData, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating JSON: %v\n", err)
+		os.Exit(utils.ExitError)
+	}
+	fmt.Println(string(jsonData))
+}
+
+// OutputDiffText prints the differences between two reports in human-readable text format
+func OutputDiffText(diff *ReportDiff) {
+	if diff.OldModule != diff.NewModule {
+		fmt.Printf("\nWARNING: Comparing reports of different modules (%s and %s)\n", diff.OldModule, diff.NewModule)
+	}
+
+	sections := []struct {
+		title   string
+		changes []DependencyChange
+	}{
+		{"Newly Unmaintained Dependencies:", diff.NewlyUnmaintained},
+		{"Recovered Dependencies:", diff.Recovered},
+		{"Other Status Changes:", diff.StatusChanges},
+	}
+	for _, section := range sections {
+		if len(section.changes) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s\n", section.title)
+		for _, change := range section.changes {
+			fmt.Printf("%s\n", change.Module)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Status: %s -> %s\n", change.OldStatus, change.NewStatus)
+			if change.OldVersion != change.NewVersion {
+				fmt.Print(strings.Repeat(" ", 10))
+				fmt.Printf("Version: %s -> %s\n", change.OldVersion, change.NewVersion)
+			}
+		}
+	}
+
+	if len(diff.Added) > 0 {
+		fmt.Println("\nAdded Dependencies:")
+		for _, change := range diff.Added {
+			fmt.Printf("%s@%s (%s)\n", change.Module, change.NewVersion, change.NewStatus)
+		}
+	}
+	if len(diff.Removed) > 0 {
+		fmt.Println("\nRemoved Dependencies:")
+		for _, change := range diff.Removed {
+			fmt.Printf("%s@%s (was %s)\n", change.Module, change.OldVersion, change.OldStatus)
+		}
+	}
+
+	if len(diff.ScoreChanges) > 0 {
+		fmt.Println("\nHealth Score Changes:")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Module\tOld\tNew\tChange")
+		for _, change := range diff.ScoreChanges {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%+d\n", change.Module, *change.OldScore, *change.NewScore, *change.NewScore-*change.OldScore)
+		}
+		tw.Flush()
+	}
+
+	fmt.Println("\nSummary:")
+	if diff.Empty() {
+		fmt.Println("- No changes")
+		return
+	}
+	fmt.Printf("- Newly Unmaintained: %d\n", len(diff.NewlyUnmaintained))
+	fmt.Printf("- Recovered: %d\n", len(diff.Recovered))
+	fmt.Printf("- Other Status Changes: %d\n", len(diff.StatusChanges))
+	fmt.Printf("- Added: %d\n", len(diff.Added))
+	fmt.Printf("- Removed: %d\n", len(diff.Removed))
+	fmt.Printf("- Health Score Changes: %d\n", len(diff.ScoreChanges))
+}
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+func setupDiffReports() (*SavedReport, *SavedReport) {
+	old := &SavedReport{Module: "github.com/test/module", Dependencies: []ping.RepoStatus{
+		{ModulePath: "github.com/going/stale", Version: "v1.0.0", Status: ping.StatusActive, Health: &ping.Health{Score: 70}},
+		{ModulePath: "github.com/back/again", Version: "v1.0.0", Status: ping.StatusStale, Health: &ping.Health{Score: 40}},
+		{ModulePath: "github.com/now/archived", Version: "v2.0.0", Status: ping.StatusStale},
+		{ModulePath: "github.com/unchanged/repo", Version: "v1.0.0", Status: ping.StatusActive, Health: &ping.Health{Score: 90}},
+		{ModulePath: "github.com/dropped/repo", Version: "v0.3.0", Status: ping.StatusArchived},
+	}}
+	new := &SavedReport{Module: "github.com/test/module", Dependencies: []ping.RepoStatus{
+		{ModulePath: "github.com/going/stale", Version: "v1.0.0", Status: ping.StatusStale, Health: &ping.Health{Score: 35}},
+		{ModulePath: "github.com/back/again", Version: "v1.1.0", Status: ping.StatusActive, Health: &ping.Health{Score: 85}},
+		{ModulePath: "github.com/now/archived", Version: "v2.0.0", Status: ping.StatusArchived},
+		{ModulePath: "github.com/unchanged/repo", Version: "v1.0.0", Status: ping.StatusActive, Health: &ping.Health{Score: 90}},
+		{ModulePath: "github.com/new/repo", Version: "v0.1.0", Status: ping.StatusActive},
+	}}
+	return old, new
+}
+
+func TestDiffReports(t *testing.T) {
+	diff := DiffReports(setupDiffReports())
+
+	modules := func(changes []DependencyChange) []string {
+		paths := []string{}
+		for _, change := range changes {
+			paths = append(paths, change.Module)
+		}
+		return paths
+	}
+	tests := []struct {
+		name     string
+		changes  []DependencyChange
+		expected []string
+	}{
+		{"Newly unmaintained", diff.NewlyUnmaintained, []string{"github.com/going/stale"}},
+		{"Recovered", diff.Recovered, []string{"github.com/back/again"}},
+		{"Status changes", diff.StatusChanges, []string{"github.com/now/archived"}},
+		{"Added", diff.Added, []string{"github.com/new/repo"}},
+		{"Removed", diff.Removed, []string{"github.com/dropped/repo"}},
+		{"Score changes, largest drop first", diff.ScoreChanges, []string{"github.com/going/stale", "github.com/back/again"}},
+	}
+	for _, tt := range tests {
+		if got := modules(tt.changes); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+
+	recovered := diff.Recovered[0]
+	if recovered.OldStatus != ping.StatusStale || recovered.NewStatus != ping.StatusActive ||
+		recovered.OldVersion != "v1.0.0" || recovered.NewVersion != "v1.1.0" || *recovered.OldScore != 40 || *recovered.NewScore != 85 {
+		t.Errorf("Unexpected change for a recovered dependency: %+v", recovered)
+	}
+	if diff.Empty() {
+		t.Errorf("Expected changes to be found")
+	}
+
+	old, _ := setupDiffReports()
+	if same := DiffReports(old, old); !same.Empty() {
+		t.Errorf("Expected no changes between identical reports, got %+v", same)
+	}
+}
+
+func TestLoadReport(t *testing.T) {
+	dir := t.TempDir()
+	_, new := setupDiffReports()
+
+	// Reports are read back from what OutputJSON writes
+	moduleInfo := setupTestModuleInfo()
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, []byte(captureOutput(func() { OutputJSON(&moduleInfo, new.Dependencies) })), 0o644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	loaded, err := LoadReport(path)
+	if err != nil {
+		t.Fatalf("LoadReport() error = %v", err)
+	}
+	if loaded.Module != moduleInfo.ModuleName || len(loaded.Dependencies) != len(new.Dependencies) ||
+		loaded.Dependencies[0].Status != ping.StatusStale || loaded.Dependencies[0].Health.Score != 35 {
+		t.Errorf("Unexpected report loaded: %+v", loaded)
+	}
+
+	other := filepath.Join(dir, "other.json")
+	os.WriteFile(other, []byte(`{"findings": []}`), 0o644)
+	if _, err := LoadReport(other); err == nil || !strings.Contains(err.Error(), "no dependencies") {
+		t.Errorf("Expected an error for a file that isn't a report, got %v", err)
+	}
+}
+
+func TestOutputDiff(t *testing.T) {
+	diff := DiffReports(setupDiffReports())
+
+	text := captureOutput(func() { OutputDiffText(diff) })
+	expectedPatterns := []string{
+		"Newly Unmaintained Dependencies:\ngithub.com/going/stale\n          Status: active -> stale\n",
+		"Recovered Dependencies:\ngithub.com/back/again\n          Status: stale -> active\n          Version: v1.0.0 -> v1.1.0\n",
+		"Other Status Changes:\ngithub.com/now/archived\n          Status: stale -> archived\n",
+		"Added Dependencies:\ngithub.com/new/repo@v0.1.0 (active)\n",
+		"Removed Dependencies:\ngithub.com/dropped/repo@v0.3.0 (was archived)\n",
+		"github.com/going/stale  70   35   -35",
+		"github.com/back/again   40   85   +45",
+		"- Newly Unmaintained: 1\n- Recovered: 1\n",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(text, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, text)
+		}
+	}
+
+	var result ReportDiff
+	if err := json.Unmarshal([]byte(captureOutput(func() { OutputDiffJSON(diff) })), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if !reflect.DeepEqual(&result, diff) {
+		t.Errorf("Expected the JSON output to hold the whole diff, got %+v", result)
+	}
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+	"github.com/Bhupesh-V/godeping/utils"
+)
+
+// OutputExplain prints the status of a single dependency along with the evidence it is based on
+func OutputExplain(repo ping.RepoStatus) {
+	fmt.Printf("\nModule: %s\n", repo.ModulePath)
+	if repo.Version != "" {
+		fmt.Printf("Version: %s\n", repo.Version)
+	}
+	fmt.Printf("Status: %s\n", verdict(repo))
+	if repo.Accepted != nil {
+		accepted := "Accepted"
+		if repo.Accepted.Expired {
+			accepted = "Acceptance expired"
+		}
+		fmt.Printf("%s: %s (owner %s, until %s)\n", accepted, repo.Accepted.Reason, repo.Accepted.Owner,
+			repo.Accepted.Expires.Format("Jan 2, 2006"))
+	}
+	if repo.Health != nil {
+		fmt.Printf("Health: %d/100\n", repo.Health.Score)
+		for _, signal := range repo.Health.Signals {
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("%s: %d (weight %g, %s)\n", signal.Name, signal.Score, signal.Weight, signal.Detail)
+		}
+	}
+
+	fmt.Println("\nEvidence:")
+	if len(repo.Evidence) == 0 {
+		fmt.Println("(none)")
+	}
+	for i, evidence := range repo.Evidence {
+		fmt.Printf("%d. [%s] %s: %s", i+1, evidence.Source, evidence.Signal, evidence.Value)
+		if !evidence.Timestamp.IsZero() {
+			fmt.Printf(" (%s)", evidence.Timestamp.Format("Jan 2, 2006"))
+		}
+		fmt.Println()
+		if evidence.URL != "" {
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("%s\n", evidence.URL)
+		}
+	}
+}
+
+// OutputExplainJSON prints the full status of a single dependency in JSON format
+func OutputExplainJSON(repo ping.RepoStatus) {
+	jsonData, err := json.MarshalIndent(repo, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating JSON: %v\n", err)
+		os.Exit(utils.ExitError)
+	}
+	fmt.Println(string(jsonData))
+}
+
+// verdict summarises the status of a dependency in a few words
+func verdict(repo ping.RepoStatus) string {
+	var status string
+	switch {
+	case repo.Incomplete:
+		status = "Incomplete"
+	case repo.Private, repo.NotCached:
+		status = "Not checked"
+	case repo.Status == ping.StatusArchived:
+		status = "Archived"
+	case repo.Status == ping.StatusDeprecated:
+		status = "Deprecated"
+	case repo.Status == ping.StatusNotFound:
+		status = "Not found"
+	case repo.Status == ping.StatusStale:
+		status = "Stale"
+	case repo.Status == ping.StatusUnknown:
+		status = "Unknown: " + repo.Error
+	default:
+		status = "Active"
+	}
+	if repo.Reason != "" {
+		status += " (" + repo.Reason + ")"
+	}
+	return status
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+func TestOutputExplain(t *testing.T) {
+	repo := ping.RepoStatus{
+		ModulePath: "github.com/archived/repo",
+		Version:    "v1.0.0",
+		Status:     ping.StatusArchived,
+		Reason:     "Repository archived on GitHub",
+		Health: &ping.Health{Score: 40, Signals: []ping.HealthSignal{
+			{Name: ping.HealthMaintenance, Score: 0, Weight: 25, Detail: "repository archived"},
+			{Name: ping.HealthRecency, Score: 80, Weight: 25, Detail: "published Mar 4, 2021"},
+		}},
+		Evidence: []ping.Evidence{
+			{Source: "proxy.golang.org", Signal: ping.SignalLatestRelease, Value: "v1.2.0", Timestamp: time.Date(2021, time.March, 4, 0, 0, 0, 0, time.UTC), URL: "https://proxy.golang.org/github.com/archived/repo/@v/v1.2.0.info"},
+			{Source: "GitHub", Signal: ping.SignalRepoArchived, Value: "archived", URL: "https://github.com/archived/repo"},
+		},
+	}
+
+	text := captureOutput(func() { OutputExplain(repo) })
+	expectedPatterns := []string{
+		"Module: github.com/archived/repo\nVersion: v1.0.0\nStatus: Archived (Repository archived on GitHub)\nHealth: 40/100\n",
+		"          maintenance: 0 (weight 25, repository archived)\n          recency: 80 (weight 25, published Mar 4, 2021)\n",
+		"1. [proxy.golang.org] latest_release: v1.2.0 (Mar 4, 2021)\n          https://proxy.golang.org/github.com/archived/repo/@v/v1.2.0.info",
+		"2. [GitHub] repo_archived: archived\n          https://github.com/archived/repo",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(text, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, text)
+		}
+	}
+
+	var decoded ping.RepoStatus
+	if err := json.Unmarshal([]byte(captureOutput(func() { OutputExplainJSON(repo) })), &decoded); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(decoded.Evidence) != 2 || decoded.Status != ping.StatusArchived || decoded.Reason != repo.Reason {
+		t.Errorf("Expected the full status in JSON output, got %+v", decoded)
+	}
+}
+
+func TestVerdict(t *testing.T) {
+	tests := map[string]ping.RepoStatus{
+		"Active": {Status: ping.StatusActive},
+		"Archived (Repository archived on GitHub)":   {Status: ping.StatusArchived, Reason: "Repository archived on GitHub"},
+		"Deprecated (Deprecated: use x)":             {Status: ping.StatusDeprecated, Deprecated: "use x", Reason: "Deprecated: use x"},
+		"Not found (404 from proxy.golang.org)":      {Status: ping.StatusNotFound, Reason: "404 from proxy.golang.org"},
+		"Stale (Not updated since then)":             {Status: ping.StatusStale, Reason: "Not updated since then"},
+		"Unknown: boom":                              {Status: ping.StatusUnknown, Error: "boom"},
+		"Not checked (Private module, not checked)":  {Status: ping.StatusUnknown, Private: true, Reason: "Private module, not checked"},
+		"Incomplete (Not checked: context canceled)": {Status: ping.StatusUnknown, Incomplete: true, Error: "context canceled", Reason: "Not checked: context canceled"},
+	}
+	for expected, repo := range tests {
+		if got := verdict(repo); got != expected {
+			t.Errorf("verdict(%+v) = %q, want %q", repo, got, expected)
+		}
+	}
+}
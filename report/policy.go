@@ -0,0 +1,162 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+// Policy decides which findings fail a run, for use as a CI gate
+type Policy struct {
+	Statuses  map[ping.Status]bool // Dependencies with one of these statuses violate the policy
+	Retracted bool                 // Dependencies requiring a retracted version violate the policy
+	MinScore  int                  // Dependencies with a lower health score violate the policy, 0 to disable
+	MaxCount  int                  // Violations tolerated before the run fails
+
+	conditions []string
+}
+
+// ParsePolicy parses -fail-on conditions. Each is a comma-separated list of statuses
+// (archived, deprecated, not_found, stale, unknown), retracted, score<N and count>N.
+// A dependency violates the policy if it matches any of them, and the run fails when
+// more than count dependencies do (none by default). With only a count, the
+// unmaintained dependencies (archived, not_found and stale) are counted.
+func ParsePolicy(conditions []string) (*Policy, error) {
+	policy := &Policy{Statuses: make(map[ping.Status]bool)}
+	counted := false
+	for _, list := range conditions {
+		for _, condition := range strings.Split(list, ",") {
+			condition = strings.TrimSpace(condition)
+			if condition == "" {
+				continue
+			}
+			policy.conditions = append(policy.conditions, condition)
+
+			if value, ok := strings.CutPrefix(condition, "score<"); ok {
+				score, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || score < 1 || score > 100 {
+					return nil, fmt.Errorf("invalid condition %q, the score must be between 1 and 100", condition)
+				}
+				policy.MinScore = score
+				continue
+			}
+			if value, ok := strings.CutPrefix(condition, "count>"); ok {
+				count, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || count < 0 {
+					return nil, fmt.Errorf("invalid condition %q, the count must be a non-negative number", condition)
+				}
+				policy.MaxCount, counted = count, true
+				continue
+			}
+			if condition == "retracted" {
+				policy.Retracted = true
+				continue
+			}
+
+			status := ping.Status(condition)
+			if status == ping.StatusActive || status.Precedence() == len(ping.Statuses) {
+				return nil, fmt.Errorf("unknown condition %q, expected one of archived, deprecated, not_found, stale, unknown, retracted, score<N, count>N", condition)
+			}
+			policy.Statuses[status] = true
+		}
+	}
+
+	if counted && len(policy.Statuses) == 0 && !policy.Retracted && policy.MinScore == 0 {
+		for _, status := range ping.Statuses {
+			if status.Unmaintained() {
+				policy.Statuses[status] = true
+			}
+		}
+	}
+	return policy, nil
+}
+
+// String returns the conditions the policy was parsed from
+func (p *Policy) String() string {
+	return strings.Join(p.conditions, ",")
+}
+
+// Violations returns the dependencies that violate the policy, along with the reason for each,
+// keyed by module path. Accepted risks, baselined findings and dependencies that weren't
+// checked never do.
+func (p *Policy) Violations(repoStatus []ping.RepoStatus) map[string]string {
+	violations := make(map[string]string)
+	for _, repo := range repoStatus {
+		if repo.AcceptedRisk() || repo.Baselined || repo.Incomplete || repo.Private || repo.NotCached {
+			continue
+		}
+		var reasons []string
+		if p.Statuses[repo.Status] {
+			reasons = append(reasons, string(repo.Status))
+		}
+		if p.Retracted && repo.Retracted {
+			reasons = append(reasons, "retracted")
+		}
+		if p.MinScore > 0 && repo.Health != nil && repo.Health.Score < p.MinScore {
+			reasons = append(reasons, fmt.Sprintf("score %d", repo.Health.Score))
+		}
+		if len(reasons) > 0 {
+			violations[repo.ModulePath] = strings.Join(reasons, ", ")
+		}
+	}
+	return violations
+}
+
+// Violated reports whether more dependencies violate the policy than it tolerates
+func (p *Policy) Violated(repoStatus []ping.RepoStatus) bool {
+	return len(p.Violations(repoStatus)) > p.MaxCount
+}
+
+// OutputViolations prints the dependencies violating the policy to stderr, so that it
+// doesn't get mixed with JSON output
+func OutputViolations(p *Policy, repoStatus []ping.RepoStatus) {
+	violations := p.Violations(repoStatus)
+	if len(violations) <= p.MaxCount {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\nPolicy violated: %d dependencies match -fail-on %s (%d allowed)\n", len(violations), p, p.MaxCount)
+	printSorted(violations)
+}
+
+// Partial reports whether some dependencies couldn't be checked because lookups failed or the
+// run was interrupted. Private and uncached dependencies are skipped on purpose and don't count.
+func Partial(repoStatus []ping.RepoStatus) bool {
+	for _, repo := range repoStatus {
+		if repo.Incomplete {
+			return true
+		}
+		if repo.Status == ping.StatusUnknown && !repo.Private && !repo.NotCached {
+			return true
+		}
+	}
+	return false
+}
+
+// OutputIntroduced prints the dependencies with findings among the ones added or changed since
+// a git revision to stderr, so that a pull request can be told what it brings in
+func OutputIntroduced(p *Policy, repoStatus []ping.RepoStatus, rev string) {
+	violations := p.Violations(repoStatus)
+	introduced := make(map[string]string)
+	for _, repo := range repoStatus {
+		if hasFinding(repo, violations) && !repo.AcceptedRisk() && !repo.Baselined {
+			reason := violations[repo.ModulePath]
+			if reason == "" {
+				reason = string(repo.Status)
+				if repo.Retracted {
+					reason += ", retracted"
+				}
+			}
+			introduced[repo.ModulePath+"@"+repo.Version] = reason
+		}
+	}
+	if len(introduced) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\nThis change introduces %d dependencies with findings compared to %s:\n", len(introduced), rev)
+	printSorted(introduced)
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+func setupPolicyResults() []ping.RepoStatus {
+	return []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Version: "v1.0.0", Status: ping.StatusArchived, Health: &ping.Health{Score: 20}},
+		{ModulePath: "github.com/deprecated/repo", Version: "v1.0.0", Status: ping.StatusDeprecated, Health: &ping.Health{Score: 45}},
+		{ModulePath: "github.com/stale/repo", Version: "v1.0.0", Status: ping.StatusStale, Health: &ping.Health{Score: 60}},
+		{ModulePath: "github.com/retracted/repo", Version: "v1.0.0", Status: ping.StatusActive, Retracted: true, Health: &ping.Health{Score: 80}},
+		{ModulePath: "github.com/active/repo", Version: "v1.0.0", Status: ping.StatusActive, Health: &ping.Health{Score: 95}},
+		{ModulePath: "github.com/accepted/repo", Version: "v1.0.0", Status: ping.StatusArchived, Health: &ping.Health{Score: 10},
+			Accepted: &ping.Acceptance{Reason: "Frozen", Owner: "jdoe"}},
+		{ModulePath: "github.com/private/repo", Version: "v1.0.0", Status: ping.StatusUnknown, Private: true},
+	}
+}
+
+func TestPolicyViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		expected   map[string]string
+		violated   bool
+	}{
+		{
+			name:       "No conditions",
+			conditions: nil,
+			expected:   map[string]string{},
+			violated:   false,
+		},
+		{
+			name:       "Statuses",
+			conditions: []string{"archived", "deprecated"},
+			expected:   map[string]string{"github.com/archived/repo": "archived", "github.com/deprecated/repo": "deprecated"},
+			violated:   true,
+		},
+		{
+			name:       "Score and retracted",
+			conditions: []string{"score<50,retracted"},
+			expected: map[string]string{
+				"github.com/archived/repo":   "score 20",
+				"github.com/deprecated/repo": "score 45",
+				"github.com/retracted/repo":  "retracted",
+			},
+			violated: true,
+		},
+		{
+			name:       "Within the tolerated count",
+			conditions: []string{"stale, score<50", "count>3"},
+			expected: map[string]string{
+				"github.com/archived/repo":   "score 20",
+				"github.com/deprecated/repo": "score 45",
+				"github.com/stale/repo":      "stale",
+			},
+			violated: false,
+		},
+		{
+			name:       "Count alone applies to unmaintained dependencies",
+			conditions: []string{"count>1"},
+			expected:   map[string]string{"github.com/archived/repo": "archived", "github.com/stale/repo": "stale"},
+			violated:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy(tt.conditions)
+			if err != nil {
+				t.Fatalf("ParsePolicy() error = %v", err)
+			}
+			results := setupPolicyResults()
+			if got := policy.Violations(results); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Violations() = %v, want %v", got, tt.expected)
+			}
+			if got := policy.Violated(results); got != tt.violated {
+				t.Errorf("Violated() = %v, want %v", got, tt.violated)
+			}
+		})
+	}
+}
+
+func TestParsePolicyInvalid(t *testing.T) {
+	tests := []struct {
+		condition string
+		errorMsg  string
+	}{
+		{"dead", `unknown condition "dead"`},
+		{"active", `unknown condition "active"`},
+		{"score<0", "score must be between 1 and 100"},
+		{"score<high", "score must be between 1 and 100"},
+		{"count>-1", "count must be a non-negative number"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePolicy([]string{tt.condition})
+		if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+			t.Errorf("ParsePolicy(%q) error = %v, want it to contain %q", tt.condition, err, tt.errorMsg)
+		}
+	}
+}
+
+func TestPartial(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []ping.RepoStatus
+		expected bool
+	}{
+		{"All checked", []ping.RepoStatus{{Status: ping.StatusActive}, {Status: ping.StatusStale}}, false},
+		{"Skipped on purpose", []ping.RepoStatus{{Status: ping.StatusUnknown, Private: true}, {Status: ping.StatusUnknown, NotCached: true}}, false},
+		{"Lookup failed", []ping.RepoStatus{{Status: ping.StatusActive}, {Status: ping.StatusUnknown, Error: "connection refused"}}, true},
+		{"Interrupted", []ping.RepoStatus{{Status: ping.StatusUnknown, Incomplete: true}}, true},
+	}
+	for _, tt := range tests {
+		if got := Partial(tt.results); got != tt.expected {
+			t.Errorf("%s: Partial() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestOutputIntroduced(t *testing.T) {
+	policy, _ := ParsePolicy([]string{"score<50"})
+
+	output := captureStderr(func() { OutputIntroduced(policy, setupPolicyResults(), "origin/main") })
+
+	expected := "\nThis change introduces 4 dependencies with findings compared to origin/main:\n" +
+		"github.com/archived/repo@v1.0.0 (score 20)\n" +
+		"github.com/deprecated/repo@v1.0.0 (score 45)\n" +
+		"github.com/retracted/repo@v1.0.0 (active, retracted)\n" +
+		"github.com/stale/repo@v1.0.0 (stale)\n"
+	if output != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+}
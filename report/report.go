@@ -4,12 +4,74 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
 	ping "github.com/Bhupesh-V/godeping/ping"
+	"github.com/Bhupesh-V/godeping/utils"
 )
 
+// statusGroups holds the dependencies of each report section
+type statusGroups struct {
+	archived, deprecated, notFound, stale, unknown []ping.RepoStatus
+	private, notCached, incomplete                 []ping.RepoStatus // Unknown status because they were not checked
+	retracted                                      []ping.RepoStatus // Regardless of their status
+	accepted                                       []ping.RepoStatus // Findings acknowledged as accepted risk
+	baselined                                      []ping.RepoStatus // Findings already in the baseline
+	expired                                        []ping.RepoStatus // Acceptance lapsed, also in their status section
+}
+
+// unmaintained returns the dependencies counted as unmaintained in the summary
+func (g statusGroups) unmaintained() []ping.RepoStatus {
+	var dead []ping.RepoStatus
+	dead = append(dead, g.archived...)
+	dead = append(dead, g.notFound...)
+	return append(dead, g.stale...)
+}
+
+// groupByStatus sorts dependencies into report sections by their status
+func groupByStatus(repoStatus []ping.RepoStatus) statusGroups {
+	var groups statusGroups
+	for _, repo := range repoStatus {
+		if repo.AcceptedRisk() {
+			groups.accepted = append(groups.accepted, repo)
+			continue
+		}
+		if repo.Baselined {
+			groups.baselined = append(groups.baselined, repo)
+			continue
+		}
+		if repo.Accepted != nil && repo.Accepted.Expired {
+			groups.expired = append(groups.expired, repo)
+		}
+		if repo.Retracted {
+			groups.retracted = append(groups.retracted, repo)
+		}
+		switch {
+		case repo.Incomplete:
+			groups.incomplete = append(groups.incomplete, repo)
+		case repo.Private:
+			groups.private = append(groups.private, repo)
+		case repo.NotCached:
+			groups.notCached = append(groups.notCached, repo)
+		case repo.Status == ping.StatusArchived:
+			groups.archived = append(groups.archived, repo)
+		case repo.Status == ping.StatusDeprecated:
+			groups.deprecated = append(groups.deprecated, repo)
+		case repo.Status == ping.StatusNotFound:
+			groups.notFound = append(groups.notFound, repo)
+		case repo.Status == ping.StatusStale:
+			groups.stale = append(groups.stale, repo)
+		case repo.Status == ping.StatusUnknown:
+			groups.unknown = append(groups.unknown, repo)
+		}
+	}
+	return groups
+}
+
 // OutputJSON prints the results in JSON format
 func OutputJSON(info *parser.ModuleInfo, repoStatus []ping.RepoStatus) {
 	// Get all direct dependencies
@@ -20,34 +82,56 @@ func OutputJSON(info *parser.ModuleInfo, repoStatus []ping.RepoStatus) {
 		}
 	}
 
-	var archived []ping.RepoStatus
-	// Count archived dependencies
-	for _, repo := range repoStatus {
-		if repo.IsArchived {
-			archived = append(archived, repo)
-		}
-	}
+	groups := groupByStatus(repoStatus)
 
 	type Output struct {
-		Module               string            `json:"module"`
-		GoVersion            string            `json:"goVersion"`
-		TotalDependencies    int               `json:"totalDependencies"`
-		DirectDependencies   int               `json:"directDependencies"`
-		ArchivedDependencies []ping.RepoStatus `json:"deadDirectDependencies"`
+		Module                 string            `json:"module"`
+		GoVersion              string            `json:"goVersion"`
+		Incomplete             bool              `json:"incomplete"`
+		TotalDependencies      int               `json:"totalDependencies"`
+		DirectDependencies     int               `json:"directDependencies"`
+		DeadDependencies       []ping.RepoStatus `json:"deadDirectDependencies"` // Unmaintained: archived, not found or stale
+		ArchivedDependencies   []ping.RepoStatus `json:"archivedDirectDependencies"`
+		DeprecatedDependencies []ping.RepoStatus `json:"deprecatedDirectDependencies"`
+		NotFoundDependencies   []ping.RepoStatus `json:"notFoundDirectDependencies"`
+		StaleDependencies      []ping.RepoStatus `json:"staleDirectDependencies"`
+		UnknownDependencies    []ping.RepoStatus `json:"unknownDirectDependencies"`
+		PrivateDependencies    []ping.RepoStatus `json:"privateDirectDependencies"`
+		NotCachedDependencies  []ping.RepoStatus `json:"notCachedDirectDependencies,omitempty"`
+		IncompleteDependencies []ping.RepoStatus `json:"incompleteDirectDependencies,omitempty"`
+		RetractedDependencies  []ping.RepoStatus `json:"retractedDirectDependencies"`
+		AcceptedDependencies   []ping.RepoStatus `json:"acceptedDirectDependencies"`
+		BaselinedDependencies  []ping.RepoStatus `json:"baselinedDirectDependencies,omitempty"`
+		ExpiredAcceptances     []ping.RepoStatus `json:"expiredAcceptances,omitempty"`
+		Dependencies           []ping.RepoStatus `json:"dependencies"`
 	}
 
 	output := Output{
-		Module:               info.ModuleName,
-		GoVersion:            info.GoVersion,
-		TotalDependencies:    len(info.Requires),
-		DirectDependencies:   len(directDependencies),
-		ArchivedDependencies: archived,
+		Module:                 info.ModuleName,
+		GoVersion:              info.GoVersion,
+		Incomplete:             len(groups.incomplete) > 0,
+		TotalDependencies:      len(info.Requires),
+		DirectDependencies:     len(directDependencies),
+		DeadDependencies:       groups.unmaintained(),
+		ArchivedDependencies:   groups.archived,
+		DeprecatedDependencies: groups.deprecated,
+		NotFoundDependencies:   groups.notFound,
+		StaleDependencies:      groups.stale,
+		UnknownDependencies:    groups.unknown,
+		PrivateDependencies:    groups.private,
+		NotCachedDependencies:  groups.notCached,
+		IncompleteDependencies: groups.incomplete,
+		RetractedDependencies:  groups.retracted,
+		AcceptedDependencies:   groups.accepted,
+		BaselinedDependencies:  groups.baselined,
+		ExpiredAcceptances:     groups.expired,
+		Dependencies:           repoStatus,
 	}
 
 	jsonData, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating JSON: %v\n", err)
-		os.Exit(1)
+		os.Exit(utils.ExitError)
 	}
 	fmt.Println(string(jsonData))
 }
@@ -62,31 +146,309 @@ func OutputText(info *parser.ModuleInfo, archived []ping.RepoStatus) {
 		}
 	}
 
-	// Print summary of archived repositories
-	archivedCount := 0
+	groups := groupByStatus(archived)
+
+	// Print dependencies whose repository is archived or disabled
+	if len(groups.archived) > 0 {
+		fmt.Println("\nArchived (Dead) Direct Dependencies:")
+		for _, repo := range groups.archived {
+			fmt.Printf("%s\n", repo.ModulePath)
+			printPublished(repo)
+			if repo.Reason != "" {
+				fmt.Print(strings.Repeat(" ", 10))
+				fmt.Printf("Reason: %s\n", repo.Reason)
+			}
+			if repo.Deprecated != "" {
+				fmt.Print(strings.Repeat(" ", 10))
+				fmt.Printf("Deprecated: %s\n", repo.Deprecated)
+			}
+			if repo.RepoURL != "" {
+				fmt.Print(strings.Repeat(" ", 10))
+				fmt.Printf("Repository: %s\n", repo.RepoURL)
+			}
+			if repo.MovedTo != "" {
+				fmt.Print(strings.Repeat(" ", 10))
+				fmt.Printf("Moved To: %s\n", repo.MovedTo)
+			}
+		}
+	}
+
+	// Print dependencies their authors deprecated
+	if len(groups.deprecated) > 0 {
+		fmt.Println("\nDeprecated Direct Dependencies:")
+		for _, repo := range groups.deprecated {
+			fmt.Printf("%s\n", repo.ModulePath)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Deprecated: %s\n", repo.Deprecated)
+		}
+	}
+
+	// Print dependencies no lookup source knows about
+	if len(groups.notFound) > 0 {
+		fmt.Println("\nNot Found Direct Dependencies:")
+		for _, repo := range groups.notFound {
+			fmt.Printf("%s\n", repo.ModulePath)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Reason: %s\n", repo.Reason)
+		}
+	}
+
+	// Print dependencies that haven't been published for longer than the unmaintained duration
+	if len(groups.stale) > 0 {
+		fmt.Println("\nStale Direct Dependencies:")
+		for _, repo := range groups.stale {
+			fmt.Printf("%s\n", repo.ModulePath)
+			printPublished(repo)
+			if repo.MovedTo != "" {
+				fmt.Print(strings.Repeat(" ", 10))
+				fmt.Printf("Moved To: %s\n", repo.MovedTo)
+			}
+		}
+	}
+
+	// Print dependencies that have been quiet for longer than their release history suggests
+	var quiet []ping.RepoStatus
 	for _, repo := range archived {
-		if repo.IsArchived {
-			archivedCount++
+		if repo.Cadence != nil && repo.Cadence.Anomalous && !repo.AcceptedRisk() && !repo.Baselined {
+			quiet = append(quiet, repo)
+		}
+	}
+	if len(quiet) > 0 {
+		fmt.Println("\nUnusually Quiet Direct Dependencies:")
+		for _, repo := range quiet {
+			fmt.Printf("%s\n", repo.ModulePath)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Quiet For: %.0f days (longest gap before: %.0f days, median gap: %.0f days)\n",
+				repo.Cadence.SilenceDays, repo.Cadence.LongestGapDays, repo.Cadence.MedianGapDays)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Releases: %d (%.1f per year)\n", repo.Cadence.Releases, repo.Cadence.ReleasesPerYear)
 		}
 	}
 
-	// Print archived dependencies if any
-	if archivedCount > 0 {
-		fmt.Println("\nArchived (Dead) Direct Dependencies:")
-		for _, repo := range archived {
-			if repo.IsArchived {
-				fmt.Printf("%s\n", repo.ModulePath)
-				if !repo.LastPublished.IsZero() {
-					fmt.Print(strings.Repeat(" ", 10))
-					fmt.Printf("Last Published: %s\n", repo.LastPublished.Format("Jan 2, 2006"))
+	// Print dependencies whose lookups failed
+	if len(groups.unknown) > 0 {
+		fmt.Println("\nUnknown Status Direct Dependencies:")
+		for _, repo := range groups.unknown {
+			fmt.Printf("%s\n", repo.ModulePath)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Error: %s\n", repo.Error)
+		}
+	}
+
+	// Print private dependencies that were deliberately not checked
+	if len(groups.private) > 0 {
+		fmt.Println("\nPrivate Direct Dependencies (Not Checked):")
+		for _, repo := range groups.private {
+			fmt.Printf("%s\n", repo.ModulePath)
+		}
+	}
+
+	// Print dependencies whose check was interrupted
+	if len(groups.incomplete) > 0 {
+		fmt.Println("\nNot Checked (Run Interrupted):")
+		for _, repo := range groups.incomplete {
+			fmt.Printf("%s\n", repo.ModulePath)
+		}
+	}
+
+	// Print dependencies the offline mode found nothing about in the module cache
+	if len(groups.notCached) > 0 {
+		fmt.Println("\nNot In Module Cache (Not Checked):")
+		for _, repo := range groups.notCached {
+			fmt.Printf("%s\n", repo.ModulePath)
+		}
+	}
+
+	// Print required versions that their authors retracted
+	if len(groups.retracted) > 0 {
+		fmt.Println("\nRetracted Versions In Use:")
+		for _, repo := range groups.retracted {
+			fmt.Printf("%s@%s\n", repo.ModulePath, repo.Version)
+			fmt.Print(strings.Repeat(" ", 10))
+			if repo.RetractRationale != "" {
+				fmt.Printf("Rationale: %s\n", repo.RetractRationale)
+			} else {
+				fmt.Println("Rationale: (none given)")
+			}
+		}
+	}
+
+	// Print dependencies whose findings were acknowledged, with who answers for them
+	if len(groups.accepted) > 0 {
+		fmt.Println("\nAccepted Risks:")
+		for _, repo := range groups.accepted {
+			fmt.Printf("%s\n", repo.ModulePath)
+			fmt.Print(strings.Repeat(" ", 10))
+			fmt.Printf("Status: %s\n", repo.Status)
+			printAcceptance(*repo.Accepted)
+		}
+	}
+
+	// Print acceptances that lapsed, their dependencies are reported in their status section again
+	if len(groups.expired) > 0 {
+		fmt.Println("\nExpired Acceptances (Resurfaced):")
+		for _, repo := range groups.expired {
+			fmt.Printf("%s\n", repo.ModulePath)
+			printAcceptance(*repo.Accepted)
+		}
+	}
+
+	// Print how far behind the latest release each dependency is, leaving out accepted and known findings
+	var drifted []ping.RepoStatus
+	for _, repo := range archived {
+		if repo.Drift != "" && repo.Drift != ping.DriftNone && !repo.AcceptedRisk() && !repo.Baselined {
+			drifted = append(drifted, repo)
+		}
+	}
+	if len(drifted) > 0 {
+		sort.Slice(drifted, func(i, j int) bool { return drifted[i].ModulePath < drifted[j].ModulePath })
+
+		fmt.Println("\nVersion Drift:")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Module\tCurrent\tLatest\tDrift\tCurrent Age")
+		for _, repo := range drifted {
+			latest := repo.LatestVersion
+			if repo.LatestMajorPath != "" {
+				latest = fmt.Sprintf("%s (%s)", repo.LatestMajorVersion, repo.LatestMajorPath)
+			}
+			age := "unknown"
+			if !repo.VersionPublished.IsZero() {
+				age = formatAge(time.Since(repo.VersionPublished))
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", repo.ModulePath, repo.Version, latest, repo.Drift, age)
+		}
+		tw.Flush()
+	}
+
+	// Print the health score of each checked dependency, least healthy first. Accepted and
+	// known findings are scored too, but marked as such.
+	var scored []ping.RepoStatus
+	for _, repo := range archived {
+		if repo.Health != nil {
+			scored = append(scored, repo)
+		}
+	}
+	if len(scored) > 0 {
+		sort.Slice(scored, func(i, j int) bool {
+			if scored[i].Health.Score != scored[j].Health.Score {
+				return scored[i].Health.Score < scored[j].Health.Score
+			}
+			return scored[i].ModulePath < scored[j].ModulePath
+		})
+
+		fmt.Println("\nHealth Scores:")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Module\tScore\tRecency\tCadence\tMaintenance\tDrift\tVulnerabilities")
+		for _, repo := range scored {
+			name := repo.ModulePath
+			switch {
+			case repo.AcceptedRisk():
+				name += " (accepted)"
+			case repo.Baselined:
+				name += " (in baseline)"
+			}
+			fmt.Fprintf(tw, "%s\t%d", name, repo.Health.Score)
+			for _, name := range ping.HealthSignals {
+				if signal := repo.Health.Signal(name); signal != nil {
+					fmt.Fprintf(tw, "\t%d", signal.Score)
+				} else {
+					fmt.Fprint(tw, "\t-")
 				}
 			}
+			fmt.Fprintln(tw)
 		}
+		tw.Flush()
 	}
 
 	// Print summary
 	fmt.Println("\nSummary:")
+	if len(groups.incomplete) > 0 {
+		fmt.Printf("WARNING: The run was interrupted, this report is incomplete (%d dependencies not checked)\n", len(groups.incomplete))
+	}
 	fmt.Printf("- Total Dependencies: %d\n", len(info.Requires))
 	fmt.Printf("- Direct Dependencies: %d\n", directDeps)
-	fmt.Printf("- Unmaintained Dependencies: %d\n", archivedCount)
+	fmt.Printf("- Unmaintained Dependencies: %d\n", len(groups.unmaintained()))
+	if len(groups.archived) > 0 {
+		fmt.Printf("  - Archived: %d\n", len(groups.archived))
+	}
+	if len(groups.notFound) > 0 {
+		fmt.Printf("  - Not Found: %d\n", len(groups.notFound))
+	}
+	if len(groups.stale) > 0 {
+		fmt.Printf("  - Stale: %d\n", len(groups.stale))
+	}
+	if len(groups.deprecated) > 0 {
+		fmt.Printf("- Deprecated Dependencies: %d\n", len(groups.deprecated))
+	}
+	if len(quiet) > 0 {
+		fmt.Printf("- Unusually Quiet Dependencies: %d\n", len(quiet))
+	}
+	if len(groups.unknown) > 0 {
+		fmt.Printf("- Unknown Status Dependencies: %d\n", len(groups.unknown))
+	}
+	if len(groups.retracted) > 0 {
+		fmt.Printf("- Retracted Versions In Use: %d\n", len(groups.retracted))
+	}
+	if len(drifted) > 0 {
+		fmt.Printf("- Outdated Dependencies: %d\n", len(drifted))
+	}
+	if len(groups.accepted) > 0 {
+		fmt.Printf("- Accepted Risks: %d\n", len(groups.accepted))
+	}
+	if len(groups.expired) > 0 {
+		fmt.Printf("- Expired Acceptances: %d\n", len(groups.expired))
+	}
+	if len(groups.baselined) > 0 {
+		fmt.Printf("- Known Findings (In Baseline): %d\n", len(groups.baselined))
+	}
+	if len(groups.private) > 0 {
+		fmt.Printf("- Private Dependencies (Not Checked): %d\n", len(groups.private))
+	}
+	if len(groups.notCached) > 0 {
+		fmt.Printf("- Not In Module Cache (Not Checked): %d\n", len(groups.notCached))
+	}
+}
+
+// printPublished prints when a dependency was last published, or the commit date of its pseudo-version
+func printPublished(repo ping.RepoStatus) {
+	if repo.PublishedEstimated {
+		fmt.Print(strings.Repeat(" ", 10))
+		fmt.Printf("Last Commit: %s (from pseudo-version)\n", repo.LastPublished.Format("Jan 2, 2006"))
+	} else if !repo.LastPublished.IsZero() {
+		fmt.Print(strings.Repeat(" ", 10))
+		fmt.Printf("Last Published: %s\n", repo.LastPublished.Format("Jan 2, 2006"))
+	}
+}
+
+// printAcceptance prints why a dependency's findings were accepted, by whom and until when
+func printAcceptance(acceptance ping.Acceptance) {
+	fmt.Print(strings.Repeat(" ", 10))
+	fmt.Printf("Reason: %s\n", acceptance.Reason)
+	fmt.Print(strings.Repeat(" ", 10))
+	fmt.Printf("Owner: %s\n", acceptance.Owner)
+	fmt.Print(strings.Repeat(" ", 10))
+	if acceptance.Expired {
+		fmt.Printf("Expired: %s\n", acceptance.Expires.Format("Jan 2, 2006"))
+	} else {
+		fmt.Printf("Expires: %s\n", acceptance.Expires.Format("Jan 2, 2006"))
+	}
+}
+
+// formatAge renders a duration in years, months and days, e.g. "1y 3m" or "12d"
+func formatAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	years, days := days/365, days%365
+	months, days := days/30, days%30
+
+	var parts []string
+	if years > 0 {
+		parts = append(parts, fmt.Sprintf("%dy", years))
+	}
+	if months > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", months))
+	}
+	if years == 0 && (days > 0 || months == 0) {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	return strings.Join(parts, " ")
 }
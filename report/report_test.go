@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
 	"github.com/Bhupesh-V/godeping/ping"
@@ -27,31 +28,47 @@ func setupTestModuleInfo() parser.ModuleInfo {
 // setupRepoStatusResults creates test repo status results
 func setupRepoStatusResults() []ping.RepoStatus {
 	return []ping.RepoStatus{
-		{ModulePath: "github.com/active/repo", IsArchived: false},
-		{ModulePath: "github.com/archived/repo", IsArchived: true},
+		{ModulePath: "github.com/active/repo", Status: ping.StatusActive},
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived},
 	}
 }
 
-func TestOutputJSON(t *testing.T) {
-	// Redirect stdout to capture output
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+// captureOutput returns what f prints to stdout
+func captureOutput(f func()) string {
+	return capture(&os.Stdout, f)
+}
 
-	moduleInfo := setupTestModuleInfo()
-	repoResults := setupRepoStatusResults()
+// captureStderr returns what f prints to stderr
+func captureStderr(f func()) string {
+	return capture(&os.Stderr, f)
+}
 
-	// Call the function
-	OutputJSON(&moduleInfo, repoResults)
+// capture points *stream at a pipe while f runs and returns what was written to it
+func capture(stream **os.File, f func()) string {
+	old := *stream
+	r, w, _ := os.Pipe()
+	*stream = w
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(done)
+	}()
 
-	// Restore stdout
+	f()
 	w.Close()
-	os.Stdout = old
+	*stream = old
+	<-done
+	return buf.String()
+}
 
-	// Read the captured output
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
-	output := buf.String()
+func TestOutputJSON(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := setupRepoStatusResults()
+
+	// Call the function, capturing its output
+	output := captureOutput(func() { OutputJSON(&moduleInfo, repoResults) })
 
 	// Print output for debugging
 	t.Logf("JSON Output: %s", output)
@@ -97,7 +114,7 @@ func TestOutputJSON(t *testing.T) {
 	// We expect only the archived dependencies to be in this list
 	expectedArchivedCount := 0
 	for _, status := range repoResults {
-		if status.IsArchived {
+		if status.Status == ping.StatusArchived {
 			expectedArchivedCount++
 		}
 	}
@@ -118,7 +135,7 @@ func TestOutputJSON(t *testing.T) {
 		// Check that this dependency is actually archived in our test data
 		var found bool
 		for _, repo := range repoResults {
-			if repo.ModulePath == path && repo.IsArchived {
+			if repo.ModulePath == path && repo.Status == ping.StatusArchived {
 				found = true
 				break
 			}
@@ -131,25 +148,11 @@ func TestOutputJSON(t *testing.T) {
 }
 
 func TestOutputText(t *testing.T) {
-	// Redirect stdout to capture output
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
 	moduleInfo := setupTestModuleInfo()
 	repoResults := setupRepoStatusResults()
 
-	// Call the function
-	OutputText(&moduleInfo, repoResults)
-
-	// Restore stdout
-	w.Close()
-	os.Stdout = old
-
-	// Read the captured output
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
-	output := buf.String()
+	// Call the function, capturing its output
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
 
 	t.Logf("Text Output: %s", output)
 
@@ -169,7 +172,7 @@ func TestOutputText(t *testing.T) {
 	// Make sure there's at least one archived dependency mentioned
 	archivedCount := 0
 	for _, status := range repoResults {
-		if status.IsArchived && strings.Contains(output, status.ModulePath) {
+		if status.Status == ping.StatusArchived && strings.Contains(output, status.ModulePath) {
 			archivedCount++
 		}
 	}
@@ -180,7 +183,7 @@ func TestOutputText(t *testing.T) {
 
 	// Check that the active repo is not flagged as archived in the output
 	for _, status := range repoResults {
-		if !status.IsArchived {
+		if status.Status != ping.StatusArchived {
 			activeRepoArchivedMentioned := strings.Contains(
 				strings.ToLower(output),
 				strings.ToLower(status.ModulePath+" is archived"))
@@ -191,3 +194,377 @@ func TestOutputText(t *testing.T) {
 		}
 	}
 }
+
+func TestOutputPrivateDependencies(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	moduleInfo.Requires = append(moduleInfo.Requires, parser.Dependency{Path: "corp.example.com/lib", Version: "v1.0.0"})
+	repoResults := append(setupRepoStatusResults(), ping.RepoStatus{ModulePath: "corp.example.com/lib", Private: true})
+
+	text := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+	if !strings.Contains(text, "Private Direct Dependencies (Not Checked):\ncorp.example.com/lib") {
+		t.Errorf("Expected private dependency section in text output, got: %s", text)
+	}
+	if !strings.Contains(text, "- Unmaintained Dependencies: 1") {
+		t.Errorf("Private dependency should not be counted as unmaintained, got: %s", text)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(captureOutput(func() { OutputJSON(&moduleInfo, repoResults) })), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	private, ok := result["privateDirectDependencies"].([]interface{})
+	if !ok || len(private) != 1 {
+		t.Fatalf("Expected one private dependency in JSON output, got %v", result["privateDirectDependencies"])
+	}
+	if dead := result["deadDirectDependencies"].([]interface{}); len(dead) != 1 {
+		t.Errorf("Expected one dead dependency in JSON output, got %d", len(dead))
+	}
+}
+
+func TestOutputNotCachedDependencies(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := append(setupRepoStatusResults(), ping.RepoStatus{ModulePath: "github.com/uncached/repo", NotCached: true})
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	expectedPatterns := []string{
+		"Not In Module Cache (Not Checked):\ngithub.com/uncached/repo",
+		"- Unmaintained Dependencies: 1",
+		"- Not In Module Cache (Not Checked): 1",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(output, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, output)
+		}
+	}
+}
+
+func TestOutputJSONRetries(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{{ModulePath: "github.com/active/repo", Retries: 2}}
+
+	output := captureOutput(func() { OutputJSON(&moduleInfo, repoResults) })
+
+	var result struct {
+		Dependencies []struct {
+			ModulePath string `json:"module_path"`
+			Retries    int    `json:"retries"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(result.Dependencies) != 1 || result.Dependencies[0].Retries != 2 {
+		t.Errorf("Expected the retry count in the dependencies list, got %+v", result.Dependencies)
+	}
+}
+
+func TestOutputIncompleteRun(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusStale, Reason: "Not updated since Jan 1, 2020"},
+		{ModulePath: "github.com/active/repo", Incomplete: true, Deprecated: "seen before the interrupt", Error: "context canceled"},
+	}
+
+	text := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+	expectedPatterns := []string{
+		"Not Checked (Run Interrupted):\ngithub.com/active/repo",
+		"WARNING: The run was interrupted, this report is incomplete (1 dependencies not checked)",
+		"- Unmaintained Dependencies: 1",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(text, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, text)
+		}
+	}
+	if strings.Contains(text, "Deprecated Direct Dependencies") {
+		t.Errorf("Interrupted checks should not be reported as deprecated, got: %s", text)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(captureOutput(func() { OutputJSON(&moduleInfo, repoResults) })), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if result["incomplete"] != true {
+		t.Errorf("Expected incomplete to be true, got %v", result["incomplete"])
+	}
+	if incomplete, ok := result["incompleteDirectDependencies"].([]interface{}); !ok || len(incomplete) != 1 {
+		t.Errorf("Expected one incomplete dependency, got %v", result["incompleteDirectDependencies"])
+	}
+}
+
+func TestOutputDeprecatedDependencies(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/active/repo", Status: ping.StatusDeprecated, Deprecated: "use github.com/active/v2 instead"},
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived},
+	}
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	expectedPatterns := []string{
+		"Deprecated Direct Dependencies:\ngithub.com/active/repo\n",
+		"Deprecated: use github.com/active/v2 instead",
+		"- Unmaintained Dependencies: 1",
+		"- Deprecated Dependencies: 1",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(output, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, output)
+		}
+	}
+}
+
+func TestOutputRetractedVersions(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/active/repo", Version: "v1.0.0", Retracted: true, RetractRationale: "Breaks on Windows."},
+		{ModulePath: "github.com/archived/repo", Version: "v2.0.0", Status: ping.StatusArchived, Retracted: true},
+	}
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	expectedPatterns := []string{
+		"Retracted Versions In Use:\ngithub.com/active/repo@v1.0.0\n",
+		"Rationale: Breaks on Windows.",
+		"github.com/archived/repo@v2.0.0\n          Rationale: (none given)",
+		"- Retracted Versions In Use: 2",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(output, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, output)
+		}
+	}
+}
+
+func TestOutputVersionDrift(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/active/repo", Version: "v1.0.0", LatestVersion: "v1.2.0", Drift: ping.DriftMinor,
+			VersionPublished: time.Now().AddDate(-1, -2, 0)},
+		{ModulePath: "github.com/archived/repo", Version: "v2.0.0", LatestVersion: "v2.0.0", Drift: ping.DriftMajor,
+			LatestMajorPath: "github.com/archived/repo/v3", LatestMajorVersion: "v3.1.0"},
+		{ModulePath: "github.com/current/repo", Version: "v1.0.0", LatestVersion: "v1.0.0", Drift: ping.DriftNone},
+		{ModulePath: "github.com/accepted/repo", Status: ping.StatusStale, Version: "v1.0.0", LatestVersion: "v2.0.0", Drift: ping.DriftMajor,
+			Accepted: &ping.Acceptance{Reason: "Frozen", Owner: "jdoe", Expires: time.Now().AddDate(1, 0, 0)}},
+		{ModulePath: "github.com/baselined/repo", Status: ping.StatusStale, Version: "v1.0.0", LatestVersion: "v1.1.0", Drift: ping.DriftMinor,
+			Baselined: true},
+	}
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	expectedPatterns := []string{
+		"Version Drift:",
+		"github.com/active/repo    v1.0.0   v1.2.0                                minor  1y 2m",
+		"github.com/archived/repo  v2.0.0   v3.1.0 (github.com/archived/repo/v3)  major  unknown",
+		"- Outdated Dependencies: 2",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(output, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, output)
+		}
+	}
+	if strings.Contains(output, "github.com/current/repo") {
+		t.Errorf("Up to date dependency should not be listed, got: %s", output)
+	}
+	if strings.Contains(output, "github.com/accepted/repo  ") || strings.Contains(output, "github.com/baselined/repo  ") {
+		t.Errorf("Accepted and known findings should not be listed as drifted, got: %s", output)
+	}
+}
+
+func TestOutputPseudoVersionCommitDate(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived, PublishedEstimated: true,
+			LastPublished: time.Date(2019, time.January, 2, 3, 4, 5, 0, time.UTC)},
+	}
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	if !strings.Contains(output, "Last Commit: Jan 2, 2019 (from pseudo-version)") {
+		t.Errorf("Expected the pseudo-version commit date, got: %s", output)
+	}
+	if strings.Contains(output, "Last Published:") {
+		t.Errorf("Estimated dates should not be shown as publish dates, got: %s", output)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	day := 24 * time.Hour
+	tests := map[time.Duration]string{
+		0:          "0d",
+		12 * day:   "12d",
+		45 * day:   "1m 15d",
+		400 * day:  "1y 1m",
+		730 * day:  "2y",
+		1100 * day: "3y",
+	}
+	for d, expected := range tests {
+		if got := formatAge(d); got != expected {
+			t.Errorf("formatAge(%v) = %q, want %q", d, got, expected)
+		}
+	}
+}
+
+func TestOutputStatusSections(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived, Reason: "Repository archived on GitHub"},
+		{ModulePath: "github.com/missing/repo", Status: ping.StatusNotFound, Reason: "404 from proxy.golang.org"},
+		{ModulePath: "github.com/stable/repo", Status: ping.StatusStale, Reason: "Not updated since Jan 1, 2020",
+			LastPublished: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{ModulePath: "github.com/broken/repo", Status: ping.StatusUnknown, Error: "proxy.golang.org: unexpected status 500"},
+		{ModulePath: "github.com/active/repo", Status: ping.StatusActive},
+	}
+
+	text := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+	expectedPatterns := []string{
+		"Archived (Dead) Direct Dependencies:\ngithub.com/archived/repo\n          Reason: Repository archived on GitHub\n",
+		"Not Found Direct Dependencies:\ngithub.com/missing/repo\n          Reason: 404 from proxy.golang.org\n",
+		"Stale Direct Dependencies:\ngithub.com/stable/repo\n          Last Published: Jan 1, 2020\n",
+		"Unknown Status Direct Dependencies:\ngithub.com/broken/repo\n          Error: proxy.golang.org: unexpected status 500\n",
+		"- Unmaintained Dependencies: 3\n  - Archived: 1\n  - Not Found: 1\n  - Stale: 1\n",
+		"- Unknown Status Dependencies: 1",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(text, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, text)
+		}
+	}
+	if strings.Contains(text, "github.com/active/repo") {
+		t.Errorf("Active dependency should not be listed, got: %s", text)
+	}
+
+	var result struct {
+		Dead         []ping.RepoStatus `json:"deadDirectDependencies"`
+		Archived     []ping.RepoStatus `json:"archivedDirectDependencies"`
+		NotFound     []ping.RepoStatus `json:"notFoundDirectDependencies"`
+		Stale        []ping.RepoStatus `json:"staleDirectDependencies"`
+		Unknown      []ping.RepoStatus `json:"unknownDirectDependencies"`
+		Dependencies []map[string]interface{}
+	}
+	if err := json.Unmarshal([]byte(captureOutput(func() { OutputJSON(&moduleInfo, repoResults) })), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(result.Archived) != 1 || len(result.NotFound) != 1 || len(result.Stale) != 1 || len(result.Unknown) != 1 {
+		t.Errorf("Expected one dependency per status list, got %+v", result)
+	}
+	// Dead dependencies are the ones counted as unmaintained: archived, not found and stale
+	if len(result.Dead) != 3 {
+		t.Errorf("Expected 3 dead dependencies, got %+v", result.Dead)
+	}
+	for i, dep := range result.Dependencies {
+		if dep["status"] != string(repoResults[i].Status) {
+			t.Errorf("Expected status %q for %s, got %v", repoResults[i].Status, repoResults[i].ModulePath, dep["status"])
+		}
+	}
+}
+
+func TestOutputHealthScores(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/active/repo", Status: ping.StatusActive, Health: &ping.Health{Score: 92, Signals: []ping.HealthSignal{
+			{Name: ping.HealthRecency, Score: 95, Weight: 30},
+			{Name: ping.HealthMaintenance, Score: 100, Weight: 25},
+		}}},
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived, Health: &ping.Health{Score: 12, Signals: []ping.HealthSignal{
+			{Name: ping.HealthRecency, Score: 20, Weight: 30},
+			{Name: ping.HealthCadence, Score: 30, Weight: 20},
+			{Name: ping.HealthMaintenance, Score: 0, Weight: 25},
+			{Name: ping.HealthDrift, Score: 40, Weight: 15},
+			{Name: ping.HealthVulnerabilities, Score: 50, Weight: 10},
+		}}},
+		{ModulePath: "github.com/stale/repo", Status: ping.StatusStale, Baselined: true, Health: &ping.Health{Score: 40, Signals: []ping.HealthSignal{
+			{Name: ping.HealthRecency, Score: 10, Weight: 30},
+		}}},
+		{ModulePath: "corp.example.com/lib", Status: ping.StatusUnknown, Private: true},
+	}
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	expected := "Health Scores:\n" +
+		"Module                               Score  Recency  Cadence  Maintenance  Drift  Vulnerabilities\n" +
+		"github.com/archived/repo             12     20       30       0            40     50\n" +
+		"github.com/stale/repo (in baseline)  40     10       -        -            -      -\n" +
+		"github.com/active/repo               92     95       -        100          -      -\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected output to contain %q, got: %s", expected, output)
+	}
+	if strings.Contains(output, "corp.example.com/lib  ") {
+		t.Errorf("Unscored dependency should not be listed, got: %s", output)
+	}
+}
+
+func TestOutputUnusuallyQuiet(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusStale, Cadence: &ping.Cadence{
+			Releases: 8, ReleasesPerYear: 2.25, MedianGapDays: 7, LongestGapDays: 14, SilenceDays: 182, Anomalous: true}},
+		{ModulePath: "github.com/active/repo", Status: ping.StatusActive, Cadence: &ping.Cadence{Releases: 5, SilenceDays: 900, LongestGapDays: 1100}},
+	}
+
+	output := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+
+	expectedPatterns := []string{
+		"Unusually Quiet Direct Dependencies:\ngithub.com/archived/repo\n" +
+			"          Quiet For: 182 days (longest gap before: 14 days, median gap: 7 days)\n" +
+			"          Releases: 8 (2.2 per year)\n",
+		"- Unusually Quiet Dependencies: 1",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(output, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, output)
+		}
+	}
+	if strings.Contains(output, "github.com/active/repo") {
+		t.Errorf("Dependency within its usual rhythm should not be listed, got: %s", output)
+	}
+}
+
+func TestOutputAcceptedRisks(t *testing.T) {
+	moduleInfo := setupTestModuleInfo()
+	expires := time.Date(2030, time.June, 30, 0, 0, 0, 0, time.UTC)
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Status: ping.StatusArchived, Accepted: &ping.Acceptance{
+			Pattern: "github.com/archived/*", Reason: "Vendored fork planned", Owner: "platform-team", Expires: expires}},
+		{ModulePath: "github.com/stable/repo", Status: ping.StatusStale, Accepted: &ping.Acceptance{
+			Reason: "Feature complete", Owner: "jdoe", Expires: time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC), Expired: true}},
+		{ModulePath: "github.com/active/repo", Status: ping.StatusActive, Accepted: &ping.Acceptance{
+			Reason: "Was stale once", Owner: "jdoe", Expires: expires}},
+	}
+
+	text := captureOutput(func() { OutputText(&moduleInfo, repoResults) })
+	expectedPatterns := []string{
+		"Accepted Risks:\ngithub.com/archived/repo\n          Status: archived\n" +
+			"          Reason: Vendored fork planned\n          Owner: platform-team\n          Expires: Jun 30, 2030\n",
+		"Stale Direct Dependencies:\ngithub.com/stable/repo\n",
+		"Expired Acceptances (Resurfaced):\ngithub.com/stable/repo\n" +
+			"          Reason: Feature complete\n          Owner: jdoe\n          Expired: Jan 31, 2020\n",
+		"- Unmaintained Dependencies: 1\n  - Stale: 1\n",
+		"- Accepted Risks: 1\n- Expired Acceptances: 1\n",
+	}
+	for _, pattern := range expectedPatterns {
+		if !strings.Contains(text, pattern) {
+			t.Errorf("Expected output to contain %q, got: %s", pattern, text)
+		}
+	}
+	if strings.Contains(text, "Archived (Dead)") || strings.Contains(text, "github.com/active/repo") {
+		t.Errorf("Accepted and active dependencies should not be listed as findings, got: %s", text)
+	}
+
+	var result struct {
+		Dead     []ping.RepoStatus `json:"deadDirectDependencies"`
+		Stale    []ping.RepoStatus `json:"staleDirectDependencies"`
+		Accepted []ping.RepoStatus `json:"acceptedDirectDependencies"`
+		Expired  []ping.RepoStatus `json:"expiredAcceptances"`
+	}
+	if err := json.Unmarshal([]byte(captureOutput(func() { OutputJSON(&moduleInfo, repoResults) })), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(result.Dead) != 1 || len(result.Stale) != 1 || len(result.Accepted) != 1 || len(result.Expired) != 1 {
+		t.Errorf("Unexpected grouping of accepted risks: %+v", result)
+	}
+	if result.Accepted[0].Accepted.Owner != "platform-team" {
+		t.Errorf("Expected the acceptance in the JSON output, got %+v", result.Accepted[0].Accepted)
+	}
+}
@@ -0,0 +1,258 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	ping "github.com/Bhupesh-V/godeping/ping"
+	"github.com/Bhupesh-V/godeping/utils"
+)
+
+// SARIF 2.1.0, as read by code scanning tools
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifRuleRetracted is the rule for retracted versions, which aren't a status of their own
+const sarifRuleRetracted = "retracted"
+
+// sarifLog is the root of a SARIF file, holding a single run
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule describes a kind of finding
+type sarifRule struct {
+	ID                   string            `json:"id"`
+	Name                 string            `json:"name"`
+	ShortDescription     sarifMessage      `json:"shortDescription"`
+	FullDescription      sarifMessage      `json:"fullDescription"`
+	HelpURI              string            `json:"helpUri"`
+	DefaultConfiguration sarifRuleDefaults `json:"defaultConfiguration"`
+}
+
+type sarifRuleDefaults struct {
+	Level string `json:"level"` // error, warning or note
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	Properties          map[string]any     `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"` // Nil if the line is unknown
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifSuppression marks a result that was acknowledged outside of the code scanning tool
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+// sarifRules lists one rule per status worth reporting, plus retracted versions
+var sarifRules = []sarifRule{
+	newSarifRule(string(ping.StatusArchived), "ArchivedDependency", "error",
+		"Dependency repository is archived",
+		"The repository of the dependency is archived or disabled on its forge, so it won't receive fixes anymore. Replace it or fork it."),
+	newSarifRule(string(ping.StatusDeprecated), "DeprecatedDependency", "warning",
+		"Dependency is deprecated",
+		"The author marked the module deprecated in its latest go.mod. The deprecation message usually names a replacement."),
+	newSarifRule(string(ping.StatusNotFound), "DependencyNotFound", "error",
+		"Dependency not found",
+		"No module proxy knows the module, it may have been deleted or moved."),
+	newSarifRule(string(ping.StatusStale), "StaleDependency", "warning",
+		"Dependency is stale",
+		"Nothing was published for the module within the unmaintained duration. It may be unmaintained, or just a stable library."),
+	newSarifRule(string(ping.StatusUnknown), "UnknownDependencyStatus", "note",
+		"Dependency status unknown",
+		"The lookups about the module failed, so whether it is maintained is unknown."),
+	newSarifRule(sarifRuleRetracted, "RetractedVersion", "warning",
+		"Required version is retracted",
+		"The author of the module retracted the version required in go.mod. Upgrade to a version that isn't retracted."),
+}
+
+// newSarifRule builds a rule whose help points at the judgement criteria in the README
+func newSarifRule(id, name, level, short, full string) sarifRule {
+	return sarifRule{
+		ID:                   id,
+		Name:                 name,
+		ShortDescription:     sarifMessage{Text: short},
+		FullDescription:      sarifMessage{Text: full},
+		HelpURI:              "https://github.com/Bhupesh-V/godeping#judgement-criteria",
+		DefaultConfiguration: sarifRuleDefaults{Level: level},
+	}
+}
+
+// OutputSARIF prints the findings as a SARIF 2.1.0 log, located at the require directives of
+// the go.mod file at goModPath. Accepted risks and baselined findings are reported as suppressed.
+func OutputSARIF(info *parser.ModuleInfo, repoStatus []ping.RepoStatus, goModPath string) {
+	lines := make(map[string]int, len(info.Requires))
+	for _, dep := range info.Requires {
+		lines[dep.Path] = dep.Line
+	}
+	ruleIndex := make(map[string]int, len(sarifRules))
+	for i, rule := range sarifRules {
+		ruleIndex[rule.ID] = i
+	}
+
+	results := []sarifResult{}
+	for _, repo := range repoStatus {
+		// Dependencies skipped on purpose have nothing to report
+		if repo.Private || repo.NotCached || repo.Incomplete {
+			continue
+		}
+		var ruleIDs []string
+		if _, ok := ruleIndex[string(repo.Status)]; ok {
+			ruleIDs = append(ruleIDs, string(repo.Status))
+		}
+		if repo.Retracted {
+			ruleIDs = append(ruleIDs, sarifRuleRetracted)
+		}
+
+		for _, ruleID := range ruleIDs {
+			rule := sarifRules[ruleIndex[ruleID]]
+			result := sarifResult{
+				RuleID:    rule.ID,
+				RuleIndex: ruleIndex[ruleID],
+				Level:     rule.DefaultConfiguration.Level,
+				Message:   sarifMessage{Text: sarifMessageText(repo, ruleID)},
+				Locations: []sarifLocation{sarifGoModLocation(goModPath, lines[repo.ModulePath])},
+				// Results are tracked by module, wherever its require directive moves to
+				PartialFingerprints: map[string]string{"modulePath/v1": repo.ModulePath},
+				Properties:          map[string]any{"module": repo.ModulePath, "version": repo.Version},
+			}
+			if repo.Health != nil {
+				result.Properties["healthScore"] = repo.Health.Score
+			}
+			if repo.AcceptedRisk() {
+				result.Suppressions = []sarifSuppression{{Kind: "external", Justification: fmt.Sprintf("%s (owner %s, until %s)",
+					repo.Accepted.Reason, repo.Accepted.Owner, repo.Accepted.Expires.Format("2006-01-02"))}}
+			} else if repo.Baselined {
+				result.Suppressions = []sarifSuppression{{Kind: "external", Justification: "In the baseline"}}
+			}
+			results = append(results, result)
+		}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "godeping",
+				InformationURI: "https://github.com/Bhupesh-V/godeping",
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+
+	jsonData, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating SARIF: %v\n", err)
+		os.Exit(utils.ExitError)
+	}
+	fmt.Println(string(jsonData))
+}
+
+// sarifMessageText describes the finding of a rule about a dependency in a sentence
+func sarifMessageText(repo ping.RepoStatus, ruleID string) string {
+	module := repo.ModulePath
+	if repo.Version != "" {
+		module += "@" + repo.Version
+	}
+
+	switch ruleID {
+	case sarifRuleRetracted:
+		if repo.RetractRationale != "" {
+			return module + " is retracted by its author: " + repo.RetractRationale
+		}
+		return module + " is retracted by its author"
+	case string(ping.StatusDeprecated):
+		if repo.Deprecated != "" {
+			return module + " is deprecated: " + repo.Deprecated
+		}
+		return module + " is deprecated"
+	case string(ping.StatusUnknown):
+		return module + " could not be checked: " + repo.Error
+	}
+
+	text := module + " is " + strings.ReplaceAll(ruleID, "_", " ")
+	if repo.Reason != "" {
+		text += " (" + repo.Reason + ")"
+	}
+	if repo.MovedTo != "" {
+		text += ", it moved to " + repo.MovedTo
+	}
+	return text
+}
+
+// sarifGoModLocation locates a result at a line of go.mod. Relative paths are resolved
+// against the root of the scanned sources, absolute ones are turned into file URIs.
+func sarifGoModLocation(goModPath string, line int) sarifLocation {
+	var artifact sarifArtifactLocation
+	if filepath.IsAbs(goModPath) {
+		path := filepath.ToSlash(goModPath)
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path // Windows drive letter
+		}
+		artifact.URI = (&url.URL{Scheme: "file", Path: path}).String()
+	} else {
+		artifact.URI = filepath.ToSlash(filepath.Clean(goModPath))
+		artifact.URIBaseID = "%SRCROOT%"
+	}
+
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact}}
+	if line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+	}
+	return location
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	parser "github.com/Bhupesh-V/godeping/parsers/modfile"
+	ping "github.com/Bhupesh-V/godeping/ping"
+)
+
+func TestOutputSARIF(t *testing.T) {
+	moduleInfo := parser.ModuleInfo{
+		ModuleName: "github.com/test/module",
+		Requires: []parser.Dependency{
+			{Path: "github.com/archived/repo", Version: "v1.0.0", Line: 6},
+			{Path: "github.com/stale/repo", Version: "v0.2.0", Line: 7},
+			{Path: "github.com/accepted/repo", Version: "v1.1.0", Line: 8},
+			{Path: "github.com/active/repo", Version: "v2.0.0", Line: 9},
+			{Path: "github.com/private/repo", Version: "v0.1.0", Line: 10},
+		},
+	}
+	repoResults := []ping.RepoStatus{
+		{ModulePath: "github.com/archived/repo", Version: "v1.0.0", Status: ping.StatusArchived, Reason: "Repository archived on GitHub",
+			Retracted: true, RetractRationale: "Broken build", Health: &ping.Health{Score: 12}},
+		{ModulePath: "github.com/stale/repo", Version: "v0.2.0", Status: ping.StatusStale, Reason: "Not updated since Jan 1, 2020"},
+		{ModulePath: "github.com/accepted/repo", Version: "v1.1.0", Status: ping.StatusDeprecated, Deprecated: "use github.com/other/repo",
+			Accepted: &ping.Acceptance{Reason: "Migration planned", Owner: "jdoe", Expires: time.Date(2030, time.March, 1, 0, 0, 0, 0, time.UTC)}},
+		{ModulePath: "github.com/active/repo", Version: "v2.0.0", Status: ping.StatusActive},
+		{ModulePath: "github.com/private/repo", Version: "v0.1.0", Status: ping.StatusUnknown, Private: true},
+	}
+
+	output := captureOutput(func() { OutputSARIF(&moduleInfo, repoResults, "service/go.mod") })
+
+	var log sarifLog
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("Failed to parse SARIF output: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Expected a single SARIF 2.1.0 run, got %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "godeping" || len(run.Tool.Driver.Rules) != len(sarifRules) {
+		t.Errorf("Unexpected tool: %+v", run.Tool)
+	}
+
+	expected := []struct {
+		ruleID     string
+		level      string
+		line       int
+		message    string
+		suppressed bool
+	}{
+		{"archived", "error", 6, "github.com/archived/repo@v1.0.0 is archived (Repository archived on GitHub)", false},
+		{"retracted", "warning", 6, "github.com/archived/repo@v1.0.0 is retracted by its author: Broken build", false},
+		{"stale", "warning", 7, "github.com/stale/repo@v0.2.0 is stale (Not updated since Jan 1, 2020)", false},
+		{"deprecated", "warning", 8, "github.com/accepted/repo@v1.1.0 is deprecated: use github.com/other/repo", true},
+	}
+	if len(run.Results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(expected), len(run.Results), run.Results)
+	}
+	for i, want := range expected {
+		result := run.Results[i]
+		if result.RuleID != want.ruleID || run.Tool.Driver.Rules[result.RuleIndex].ID != want.ruleID || result.Level != want.level {
+			t.Errorf("Result %d: got rule %s (index %d) at level %s, want %s at level %s", i, result.RuleID, result.RuleIndex, result.Level, want.ruleID, want.level)
+		}
+		if result.Message.Text != want.message {
+			t.Errorf("Result %d: got message %q, want %q", i, result.Message.Text, want.message)
+		}
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != "service/go.mod" || location.ArtifactLocation.URIBaseID != "%SRCROOT%" ||
+			location.Region == nil || location.Region.StartLine != want.line {
+			t.Errorf("Result %d: got location %+v, want service/go.mod:%d", i, location, want.line)
+		}
+		if suppressed := len(result.Suppressions) > 0; suppressed != want.suppressed {
+			t.Errorf("Result %d: got suppressions %+v, want suppressed %v", i, result.Suppressions, want.suppressed)
+		}
+	}
+	if justification := run.Results[3].Suppressions[0].Justification; justification != "Migration planned (owner jdoe, until 2030-03-01)" {
+		t.Errorf("Unexpected justification %q", justification)
+	}
+}
+
+func TestSarifGoModLocation(t *testing.T) {
+	location := sarifGoModLocation("/home/user/project/go.mod", 0)
+	if uri := location.PhysicalLocation.ArtifactLocation.URI; uri != "file:///home/user/project/go.mod" {
+		t.Errorf("Expected a file URI for an absolute path, got %q", uri)
+	}
+	if location.PhysicalLocation.Region != nil {
+		t.Errorf("Expected no region for an unknown line, got %+v", location.PhysicalLocation.Region)
+	}
+
+	location = sarifGoModLocation("./go.mod", 3)
+	if artifact := location.PhysicalLocation.ArtifactLocation; artifact.URI != "go.mod" || artifact.URIBaseID != "%SRCROOT%" {
+		t.Errorf("Expected go.mod relative to the source root, got %+v", artifact)
+	}
+}
@@ -6,6 +6,14 @@ import (
 	"strings"
 )
 
+// Exit codes, so that CI pipelines can tell a failed gate from a broken run
+const (
+	ExitOK              = 0 // Nothing violated the -fail-on policy
+	ExitPolicyViolation = 1 // Dependencies violated the -fail-on policy
+	ExitError           = 2 // Invalid usage, unreadable go.mod or configuration, or failed output
+	ExitPartial         = 3 // Some dependencies couldn't be checked (network failures, interrupted run)
+)
+
 // ProgressCallback returns a function that can be used to report progress
 func ProgressCallback(quiet *bool) func(string, string) {
 	return func(dep string, status string) {
@@ -22,7 +30,8 @@ func ProgressCallback(quiet *bool) func(string, string) {
 func GetUsageText() func() {
 	return func() {
 		fmt.Fprintf(os.Stdout, "godeping - Ping your Go project dependencies for aliveness (being maintained or not)\n")
-		fmt.Fprintf(os.Stdout, "\nUsage:\n  %s [options] <path-to-go-project>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stdout, "\nUsage:\n  %s [options] <path-to-go-project>\n", os.Args[0])
+		fmt.Fprintf(os.Stdout, "  %s diff [-format text|json] <old.json> <new.json>\n\n", os.Args[0])
 		fmt.Fprintln(os.Stdout, `
 Examples:
 ========
@@ -43,6 +52,49 @@ Assuming you are in the root directory of your Go project:
 	Check dependencies not updated in 1 year and 3 months:
 		godeping -since 1y3m .
 
+	Use a configuration file other than the project's .godeping.yaml:
+		godeping -config ci/godeping.yaml .
+
+	Apply the goproxy, github_api and forges settings of the project's own .godeping.yaml,
+	which are ignored when the file is only found in the project root:
+		godeping -config .godeping.yaml .
+
+	Check using only the local module cache (no network access):
+		godeping -offline .
+
+	Show why a dependency got its status:
+		godeping -explain github.com/pkg/errors .
+
+	Weigh release cadence over recency in health scores, and include known vulnerabilities:
+		godeping -weights recency=10,cadence=40 -vulns .
+
+	Fail a CI job on archived or deprecated dependencies:
+		godeping -quiet -fail-on archived,deprecated .
+
+	Fail a CI job when more than 3 dependencies are stale or score below 50:
+		godeping -quiet -fail-on stale,score<50,count>3 .
+
+	Only fail on findings that aren't in a previously written baseline:
+		godeping -fail-on stale -write-baseline baseline.json .
+		godeping -fail-on stale -baseline baseline.json .
+
+	Only check the dependencies a pull request adds or updates:
+		godeping -diff-base origin/main -fail-on stale .
+
+	Write findings as SARIF for code scanning tools:
+		godeping -format sarif . > godeping.sarif
+
+	Compare this week's report with last week's:
+		godeping -json . > this-week.json
+		godeping diff last-week.json this-week.json
+
+Exit Codes:
+==========
+	0  No dependency violated the -fail-on policy
+	1  Dependencies violated the -fail-on policy
+	2  Error, e.g. invalid flags or an unreadable go.mod
+	3  Partial results, some dependencies couldn't be checked
+
 Support:
 =======
 	https://github.com/Bhupesh-V/godeping/issues`)